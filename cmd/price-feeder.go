@@ -64,6 +64,7 @@ func init() {
 
 	rootCmd.AddCommand(getVersionCmd())
 	rootCmd.AddCommand(getBacktestCmd())
+	rootCmd.AddCommand(getProvidersCmd())
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -75,20 +76,21 @@ func Execute() {
 	}
 }
 
-func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
+// getLogger returns a logger for the log level and format flags.
+func getLogger(cmd *cobra.Command) (zerolog.Logger, error) {
 	logLvlStr, err := cmd.Flags().GetString(flagLogLevel)
 	if err != nil {
-		return err
+		return zerolog.Logger{}, err
 	}
 
 	logLvl, err := zerolog.ParseLevel(logLvlStr)
 	if err != nil {
-		return err
+		return zerolog.Logger{}, err
 	}
 
 	logFormatStr, err := cmd.Flags().GetString(flagLogFormat)
 	if err != nil {
-		return err
+		return zerolog.Logger{}, err
 	}
 
 	var logWriter io.Writer
@@ -103,11 +105,57 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 		}
 
 	default:
-		return fmt.Errorf("invalid logging format: %s", logFormatStr)
+		return zerolog.Logger{}, fmt.Errorf("invalid logging format: %s", logFormatStr)
 	}
 
 	zerolog.TimeFieldFormat = time.StampMilli
-	logger := zerolog.New(logWriter).Level(logLvl).With().Timestamp().Logger()
+	return zerolog.New(logWriter).Level(logLvl).With().Timestamp().Logger(), nil
+}
+
+// getEndpoints returns the endpoints of all configured providers, including
+// the custom providers of the generic_rest, uniswapv2 and plugin sections.
+func getEndpoints(cfg config.Config) (map[provider.Name]provider.Endpoint, error) {
+	endpoints := make(map[provider.Name]provider.Endpoint, len(cfg.ProviderEndpoints))
+	for _, e := range cfg.ProviderEndpoints {
+		endpoint, err := e.ToEndpoint(cfg.UrlSets)
+		if err != nil {
+			return nil, err
+		}
+		endpoints[endpoint.Name] = endpoint
+	}
+
+	for _, g := range cfg.GenericRest {
+		endpoint, err := g.ToEndpoint()
+		if err != nil {
+			return nil, err
+		}
+		endpoints[endpoint.Name] = endpoint
+	}
+
+	for _, u := range cfg.UniswapV2 {
+		endpoint, err := u.ToEndpoint()
+		if err != nil {
+			return nil, err
+		}
+		endpoints[endpoint.Name] = endpoint
+	}
+
+	for _, p := range cfg.Plugins {
+		endpoint, err := p.ToEndpoint()
+		if err != nil {
+			return nil, err
+		}
+		endpoints[endpoint.Name] = endpoint
+	}
+
+	return endpoints, nil
+}
+
+func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
+	logger, err := getLogger(cmd)
+	if err != nil {
+		return err
+	}
 
 	cfg, err := config.ParseConfig(args[0])
 	if err != nil {
@@ -172,35 +220,9 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse provider timeout: %w", err)
 	}
 
-	deviations := make(map[string]math.LegacyDec, len(cfg.Deviations))
-	for _, deviation := range cfg.Deviations {
-		threshold, err := math.LegacyNewDecFromStr(deviation.Threshold)
-		if err != nil {
-			return err
-		}
-		deviations[deviation.Base] = threshold
-	}
-
-	providerMinOverrides := make(map[string]int, len(cfg.ProviderMinOverrides))
-	for _, override := range cfg.ProviderMinOverrides {
-		for _, denom := range override.Denoms {
-			_, found := providerMinOverrides[denom]
-			if found {
-				logger.Warn().
-					Str("denom", denom).
-					Msg("provider_min_overrides already set")
-			}
-			providerMinOverrides[denom] = int(override.Providers)
-		}
-	}
-
-	endpoints := make(map[provider.Name]provider.Endpoint, len(cfg.ProviderEndpoints))
-	for _, e := range cfg.ProviderEndpoints {
-		endpoint, err := e.ToEndpoint(cfg.UrlSets)
-		if err != nil {
-			return err
-		}
-		endpoints[endpoint.Name] = endpoint
+	settings, err := getOracleSettings(cfg, logger)
+	if err != nil {
+		return err
 	}
 
 	history, err := history.NewPriceHistory(cfg.HistoryDb, logger)
@@ -211,7 +233,6 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 	derivativePairs := map[string][]types.CurrencyPair{}
 	derivativePeriods := map[string]map[string]time.Duration{}
 	derivativeSymbols := map[string]struct{}{}
-	providerPairs := []config.CurrencyPair{}
 	for _, pair := range cfg.CurrencyPairs {
 		if pair.Derivative != "" {
 			period, err := time.ParseDuration(pair.DerivativePeriod)
@@ -228,7 +249,6 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 			derivativePeriods[pair.Derivative][currencyPair.String()] = period
 			derivativeSymbols[pair.Base+pair.Quote] = struct{}{}
 		}
-		providerPairs = append(providerPairs, pair)
 	}
 
 	derivatives := map[string]derivative.Derivative{}
@@ -240,28 +260,6 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 		derivatives[name] = d
 	}
 
-	providerWeights := map[string]oracle.ProviderWeight{}
-	for denom, weights := range cfg.ProviderWeights {
-		newWeight := oracle.ProviderWeight{
-			Type:   "simple",
-			Weight: map[string]math.LegacyDec{},
-		}
-
-		for providerName, value := range weights {
-			if value < 0 {
-				return fmt.Errorf("override must be >= 0")
-			}
-
-			value, err := math.LegacyNewDecFromStr(fmt.Sprintf("%f", value))
-			if err != nil {
-				return err
-			}
-			newWeight.Weight[providerName] = value
-		}
-
-		providerWeights[denom] = newWeight
-	}
-
 	volumeDatabase, err := sql.Open("sqlite3", cfg.HistoryDb)
 	if err != nil {
 		logger.Err(err).
@@ -273,24 +271,32 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 	oracle := oracle.New(
 		logger,
 		oracleClient,
-		providerPairs,
+		settings.CurrencyPairs,
 		providerTimeout,
-		deviations,
-		providerMinOverrides,
-		endpoints,
+		settings.Deviations,
+		settings.ProviderMinOverrides,
+		settings.Endpoints,
 		derivatives,
 		derivativePairs,
 		derivativeSymbols,
 		cfg.Healthchecks,
 		history,
-		cfg.ContractAdresses,
-		providerWeights,
-		cfg.Decimals,
-		cfg.Periods,
+		settings.ContractAddresses,
+		settings.ProviderWeights,
+		settings.LiquidityFilters,
+		settings.Pegs,
+		settings.Synthetics,
+		settings.Precisions,
+		settings.Decimals,
+		settings.Periods,
 		volumeDatabase,
 		cfg.BypassOracleParams,
 	)
 
+	// reload the reloadable settings on SIGHUP and, if enabled, via the api
+	reloader := newReloader(args[0], cfg, logger, oracle)
+	trapReload(ctx, reloader, logger)
+
 	telemetryCfg := telemetry.Config{}
 	err = mapstructure.Decode(cfg.Telemetry, &telemetryCfg)
 	if err != nil {
@@ -304,7 +310,7 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 	if cfg.EnableServer {
 		g.Go(func() error {
 			// start the process that observes and publishes exchange prices
-			return startPriceFeeder(ctx, logger, cfg, oracle, metrics)
+			return startPriceFeeder(ctx, logger, cfg, oracle, metrics, reloader)
 		})
 	}
 
@@ -320,6 +326,150 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 	return g.Wait()
 }
 
+// getOracleSettings returns the reloadable oracle settings of the config.
+func getOracleSettings(cfg config.Config, logger zerolog.Logger) (oracle.Settings, error) {
+	endpoints, err := getEndpoints(cfg)
+	if err != nil {
+		return oracle.Settings{}, err
+	}
+
+	deviations := make(map[string]math.LegacyDec, len(cfg.Deviations))
+	for _, deviation := range cfg.Deviations {
+		threshold, err := math.LegacyNewDecFromStr(deviation.Threshold)
+		if err != nil {
+			return oracle.Settings{}, err
+		}
+		deviations[deviation.Base] = threshold
+	}
+
+	providerMinOverrides := make(map[string]int, len(cfg.ProviderMinOverrides))
+	for _, override := range cfg.ProviderMinOverrides {
+		for _, denom := range override.Denoms {
+			_, found := providerMinOverrides[denom]
+			if found {
+				logger.Warn().
+					Str("denom", denom).
+					Msg("provider_min_overrides already set")
+			}
+			providerMinOverrides[denom] = int(override.Providers)
+		}
+	}
+
+	providerWeights := map[string]oracle.ProviderWeight{}
+	for denom, weights := range cfg.ProviderWeights {
+		newWeight := oracle.ProviderWeight{
+			Type:   "simple",
+			Weight: map[string]math.LegacyDec{},
+		}
+
+		for providerName, value := range weights {
+			if value < 0 {
+				return oracle.Settings{}, fmt.Errorf("override must be >= 0")
+			}
+
+			value, err := math.LegacyNewDecFromStr(fmt.Sprintf("%f", value))
+			if err != nil {
+				return oracle.Settings{}, err
+			}
+			newWeight.Weight[providerName] = value
+		}
+
+		providerWeights[denom] = newWeight
+	}
+
+	liquidityFilters := map[string]oracle.LiquidityFilter{}
+	for _, filter := range cfg.LiquidityFilters {
+		minTvl := math.LegacyZeroDec()
+		if filter.MinTvl != "" {
+			minTvl, err = math.LegacyNewDecFromStr(filter.MinTvl)
+			if err != nil {
+				return oracle.Settings{}, err
+			}
+		}
+
+		for _, denom := range filter.Denoms {
+			_, found := liquidityFilters[denom]
+			if found {
+				logger.Warn().
+					Str("denom", denom).
+					Msg("liquidity_filters already set")
+			}
+			liquidityFilters[denom] = oracle.LiquidityFilter{
+				MinTvl:   minTvl,
+				Weighted: filter.WeightByLiquidity,
+			}
+		}
+	}
+
+	pegs := []oracle.Peg{}
+	for _, peg := range cfg.Pegs {
+		rate, err := math.LegacyNewDecFromStr(peg.Rate)
+		if err != nil {
+			return oracle.Settings{}, err
+		}
+
+		var band math.LegacyDec
+		if peg.Band != "" {
+			band, err = math.LegacyNewDecFromStr(peg.Band)
+			if err != nil {
+				return oracle.Settings{}, err
+			}
+		}
+
+		pegs = append(pegs, oracle.Peg{
+			Base:  peg.Base,
+			Quote: peg.Quote,
+			Rate:  rate,
+			Band:  band,
+		})
+	}
+
+	formulas := map[string]string{}
+	for _, synthetic := range cfg.Synthetics {
+		formulas[strings.ToUpper(synthetic.Denom)] = synthetic.Formula
+	}
+
+	synthetics, err := oracle.NewSynthetics(formulas)
+	if err != nil {
+		return oracle.Settings{}, err
+	}
+
+	precisions := map[string]oracle.Precision{}
+	for _, precision := range cfg.Precisions {
+		mode, err := oracle.NewRoundingMode(precision.Rounding)
+		if err != nil {
+			return oracle.Settings{}, err
+		}
+
+		p := oracle.Precision{Mode: mode}
+		if precision.SignificantFigures != nil {
+			p.SignificantFigures = *precision.SignificantFigures
+		}
+		if precision.DecimalPlaces != nil {
+			p.DecimalPlaces = *precision.DecimalPlaces
+		}
+
+		for _, denom := range precision.Denoms {
+			precisions[denom] = p
+		}
+	}
+
+	return oracle.Settings{
+		CurrencyPairs:        cfg.CurrencyPairs,
+		Deviations:           deviations,
+		ProviderMinOverrides: providerMinOverrides,
+		Endpoints:            endpoints,
+		ContractAddresses:    cfg.ContractAdresses,
+		ProviderWeights:      providerWeights,
+		LiquidityFilters:     liquidityFilters,
+		Pegs:                 pegs,
+		Synthetics:           synthetics,
+		Precisions:           precisions,
+		Decimals:             cfg.Decimals,
+		Periods:              cfg.Periods,
+	}, nil
+}
+
 func getKeyringPassword() (string, error) {
 	reader := bufio.NewReader(os.Stdin)
 
@@ -351,9 +501,11 @@ func startPriceFeeder(
 	cfg config.Config,
 	oracle *oracle.Oracle,
 	metrics *telemetry.Metrics,
+	reloader v1.Reloader,
 ) error {
 	rtr := mux.NewRouter()
 	v1Router := v1.New(logger, cfg, oracle, metrics)
+	v1Router.SetReloader(reloader)
 	v1Router.RegisterRoutes(rtr, v1.APIPathPrefix)
 
 	writeTimeout, err := time.ParseDuration(cfg.Server.WriteTimeout)
@@ -412,6 +564,7 @@ func startPriceOracle(ctx context.Context, logger zerolog.Logger, oracle *oracle
 		select {
 		case <-ctx.Done():
 			logger.Info().Msg("shutting down price-feeder oracle...")
+			oracle.Stop()
 			return nil
 
 		case err := <-srvErrCh:
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"price-feeder/config"
+	"price-feeder/oracle"
+	"price-feeder/oracle/provider"
+	"price-feeder/oracle/types"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagProvider  = "provider"
+	flagPair      = "pair"
+	flagPolls     = "polls"
+	flagInterval  = "interval"
+	flagUrl       = "url"
+	flagWebsocket = "websocket"
+
+	defaultProbeInterval = 5 * time.Second
+)
+
+type probeResult struct {
+	provider  provider.Name
+	pair      types.CurrencyPair
+	ticker    *types.TickerPrice
+	available string
+	err       error
+}
+
+func getProvidersCmd() *cobra.Command {
+	providersCmd := &cobra.Command{
+		Use:   "providers",
+		Short: "Inspect price providers",
+	}
+
+	testCmd := &cobra.Command{
+		Use:   "test [config-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Fetch the configured pairs from the providers without a chain connection",
+		Long: `Constructs only the providers of the configured currency pairs, waits
+for a number of polls and prints the prices, volumes and timestamps of each
+pair, whether the provider lists the pair in its available pairs and any
+error. Endpoints can be overridden to test against local fixture servers, ex.:
+
+  price-feeder providers test config.toml --provider binance --pair ATOMUSDT \
+    --url binance=http://localhost:8080 --websocket binance=ws://localhost:8081`,
+		RunE: providersTestCmdHandler,
+	}
+
+	testCmd.Flags().StringSlice(flagProvider, nil, "only test these providers")
+	testCmd.Flags().StringSlice(flagPair, nil, "only test these pairs, ex.: ATOMUSDT")
+	testCmd.Flags().Int(flagPolls, 3, "number of polls to wait for")
+	testCmd.Flags().Duration(flagInterval, 0, "time between polls, defaults to the largest poll interval of the providers")
+	testCmd.Flags().StringArray(flagUrl, nil, "override the rest urls of a provider, ex.: binance=http://localhost:8080")
+	testCmd.Flags().StringArray(flagWebsocket, nil, "override the websocket of a provider, ex.: binance=ws://localhost:8081")
+
+	providersCmd.AddCommand(testCmd)
+
+	return providersCmd
+}
+
+func providersTestCmdHandler(cmd *cobra.Command, args []string) error {
+	logger, err := getLogger(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ParseConfig(args[0])
+	if err != nil {
+		return err
+	}
+
+	providerFilter, err := cmd.Flags().GetStringSlice(flagProvider)
+	if err != nil {
+		return err
+	}
+	pairFilter, err := cmd.Flags().GetStringSlice(flagPair)
+	if err != nil {
+		return err
+	}
+	polls, err := cmd.Flags().GetInt(flagPolls)
+	if err != nil {
+		return err
+	}
+	interval, err := cmd.Flags().GetDuration(flagInterval)
+	if err != nil {
+		return err
+	}
+	urlOverrides, err := getOverrides(cmd, flagUrl)
+	if err != nil {
+		return err
+	}
+	websocketOverrides, err := getOverrides(cmd, flagWebsocket)
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := getEndpoints(cfg)
+	if err != nil {
+		return err
+	}
+
+	providerPairs := filterProviderPairs(cfg.CurrencyPairs, providerFilter, pairFilter)
+	if len(providerPairs) == 0 {
+		return fmt.Errorf("no matching providers and pairs in config")
+	}
+
+	for name, url := range urlOverrides {
+		endpoint := endpoints[name]
+		endpoint.Urls = strings.Split(url, ",")
+		endpoints[name] = endpoint
+	}
+	for name, websocket := range websocketOverrides {
+		endpoint := endpoints[name]
+		endpoint.Websocket = websocket
+		endpoints[name] = endpoint
+	}
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	// volumes are kept in memory only
+	var db *sql.DB
+	for name := range providerPairs {
+		endpoint := endpoints[name]
+		endpoint.Name = name
+		registration, found := provider.Lookup(endpoint.TypeName())
+		if found && registration.NeedsDb {
+			db, err = sql.Open("sqlite3", ":memory:")
+			if err != nil {
+				return err
+			}
+			db.SetMaxOpenConns(1)
+			defer db.Close()
+			break
+		}
+	}
+
+	// stop polling before the database is closed
+	scheduler := provider.NewScheduler(logger)
+	defer scheduler.Stop()
+	ctx = provider.WithScheduler(ctx, scheduler)
+
+	names := make([]provider.Name, 0, len(providerPairs))
+	for name := range providerPairs {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	providers := map[provider.Name]provider.Provider{}
+	results := []*probeResult{}
+	for _, name := range names {
+		pairs := providerPairs[name]
+
+		endpoint := endpoints[name]
+		endpoint.ContractAddresses = cfg.ContractAdresses[name.String()]
+		if endpoint.ContractAddresses == nil {
+			endpoint.ContractAddresses = map[string]string{}
+		}
+		endpoint.Decimals = cfg.Decimals[name.String()]
+		endpoint.Periods = cfg.Periods[name.String()]
+
+		if interval == 0 {
+			defaults := endpoint
+			defaults.Name = name
+			defaults.ContractAddresses = map[string]string{}
+			defaults.SetDefaults()
+			if defaults.PollInterval > interval {
+				interval = defaults.PollInterval
+			}
+		}
+
+		p, err := oracle.NewProvider(db, ctx, name, logger, endpoint, pairs...)
+
+		var available map[string]struct{}
+		var availableErr error
+		if err == nil {
+			providers[name] = p
+			available, availableErr = p.GetAvailablePairs()
+		}
+
+		for _, pair := range pairs {
+			result := &probeResult{provider: name, pair: pair, err: err}
+			switch {
+			case err != nil:
+				result.available = "-"
+			case availableErr != nil:
+				result.available = "error"
+			default:
+				result.available = "no"
+				if _, found := available[p.CurrencyPairToProviderPair(pair)]; found {
+					result.available = "yes"
+				}
+			}
+			results = append(results, result)
+		}
+	}
+
+	if interval == 0 {
+		interval = defaultProbeInterval
+	}
+
+	// the results reflect the last poll
+	for i := 0; i < polls; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		for _, result := range results {
+			p, found := providers[result.provider]
+			if !found {
+				continue
+			}
+
+			result.ticker = nil
+
+			tickers, err := p.GetTickerPrices(result.pair)
+			if err != nil {
+				result.err = err
+				continue
+			}
+
+			ticker, found := tickers[result.pair.String()]
+			if !found {
+				result.err = fmt.Errorf("no price after %d polls", i+1)
+				continue
+			}
+			result.ticker = &ticker
+			result.err = nil
+		}
+	}
+
+	failed := printProbeResults(os.Stdout, results)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d pairs failed", failed, len(results))
+	}
+	return nil
+}
+
+// filterProviderPairs returns the configured pairs of each provider that
+// match the filters, empty filters match everything.
+func filterProviderPairs(
+	currencyPairs []config.CurrencyPair,
+	providerFilter []string,
+	pairFilter []string,
+) map[provider.Name][]types.CurrencyPair {
+	matches := func(filter []string, value string) bool {
+		if len(filter) == 0 {
+			return true
+		}
+		for _, f := range filter {
+			if strings.EqualFold(f, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	providerPairs := map[provider.Name][]types.CurrencyPair{}
+	for _, cp := range currencyPairs {
+		pair := types.CurrencyPair{Base: cp.Base, Quote: cp.Quote}
+		if !matches(pairFilter, pair.String()) {
+			continue
+		}
+		for _, name := range cp.Providers {
+			if !matches(providerFilter, name.String()) {
+				continue
+			}
+			providerPairs[name] = append(providerPairs[name], pair)
+		}
+	}
+	return providerPairs
+}
+
+// getOverrides parses the name=value flags of endpoint overrides.
+func getOverrides(cmd *cobra.Command, flag string) (map[provider.Name]string, error) {
+	values, err := cmd.Flags().GetStringArray(flag)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[provider.Name]string{}
+	for _, value := range values {
+		name, override, found := strings.Cut(value, "=")
+		if !found || name == "" || override == "" {
+			return nil, fmt.Errorf("invalid --%s %s, expected provider=value", flag, value)
+		}
+		overrides[provider.Name(name)] = override
+	}
+	return overrides, nil
+}
+
+// printProbeResults writes the results as a table and returns the number of
+// pairs without a price.
+func printProbeResults(w io.Writer, results []*probeResult) int {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROVIDER\tPAIR\tPRICE\tVOLUME\tTIME\tAVAILABLE\tERROR")
+
+	failed := 0
+	for _, result := range results {
+		price, volume, timestamp, errMsg := "-", "-", "-", ""
+		if result.ticker != nil {
+			price = result.ticker.Price.String()
+			if !result.ticker.Volume.IsNil() {
+				volume = result.ticker.Volume.String()
+			}
+			timestamp = result.ticker.Time.UTC().Format(time.RFC3339)
+		} else {
+			failed++
+		}
+		if result.err != nil {
+			errMsg = result.err.Error()
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			result.provider, result.pair, price, volume, timestamp, result.available, errMsg,
+		)
+	}
+	tw.Flush()
+
+	return failed
+}
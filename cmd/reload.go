@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+
+	"github.com/rs/zerolog"
+
+	"price-feeder/config"
+	"price-feeder/oracle"
+)
+
+// reloader re-reads the config file and applies its reloadable settings to
+// the running oracle. Invalid configs are rejected and leave the oracle
+// untouched.
+type reloader struct {
+	path   string
+	logger zerolog.Logger
+	oracle *oracle.Oracle
+
+	mtx sync.Mutex
+	cfg config.Config
+}
+
+func newReloader(path string, cfg config.Config, logger zerolog.Logger, oracle *oracle.Oracle) *reloader {
+	return &reloader{
+		path:   path,
+		logger: logger,
+		oracle: oracle,
+		cfg:    cfg,
+	}
+}
+
+// Reload parses the config file and applies it to the oracle.
+func (r *reloader) Reload() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	cfg, err := config.ParseConfig(r.path)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	settings, err := getOracleSettings(cfg, r.logger)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	if err := r.oracle.Reload(settings); err != nil {
+		return err
+	}
+
+	for _, section := range restartSections(r.cfg, cfg) {
+		r.logger.Warn().
+			Str("section", section).
+			Msg("config changes are only applied on restart")
+	}
+
+	r.cfg = cfg
+	return nil
+}
+
+// restartSections returns the changed parts of the config that are not
+// reloaded.
+func restartSections(running, updated config.Config) []string {
+	sections := []struct {
+		name    string
+		running interface{}
+		updated interface{}
+	}{
+		{name: "server", running: running.Server, updated: updated.Server},
+		{name: "account", running: running.Account, updated: updated.Account},
+		{name: "keyring", running: running.Keyring, updated: updated.Keyring},
+		{name: "rpc", running: running.RPC, updated: updated.RPC},
+		{name: "telemetry", running: running.Telemetry, updated: updated.Telemetry},
+		{name: "healthchecks", running: running.Healthchecks, updated: updated.Healthchecks},
+		{name: "gas_adjustment", running: running.GasAdjustment, updated: updated.GasAdjustment},
+		{name: "gas_prices", running: running.GasPrices, updated: updated.GasPrices},
+		{name: "provider_timeout", running: running.ProviderTimeout, updated: updated.ProviderTimeout},
+		{name: "height_poll_interval", running: running.HeightPollInterval, updated: updated.HeightPollInterval},
+		{name: "history_db", running: running.HistoryDb, updated: updated.HistoryDb},
+		{name: "enable_server", running: running.EnableServer, updated: updated.EnableServer},
+		{name: "enable_voter", running: running.EnableVoter, updated: updated.EnableVoter},
+		{name: "bypass_oracle_params", running: running.BypassOracleParams, updated: updated.BypassOracleParams},
+	}
+
+	changed := []string{}
+	for _, section := range sections {
+		if !reflect.DeepEqual(section.running, section.updated) {
+			changed = append(changed, section.name)
+		}
+	}
+	return changed
+}
+
+// trapReload reloads the config on every SIGHUP until ctx is done.
+func trapReload(ctx context.Context, r *reloader, logger zerolog.Logger) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sigCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigCh:
+				logger.Info().Msg("caught SIGHUP; reloading config...")
+				if err := r.Reload(); err != nil {
+					logger.Error().Err(err).Msg("failed to reload config")
+				}
+			}
+		}
+	}()
+}
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strings"
 	"time"
 
 	"price-feeder/oracle/derivative"
+	"price-feeder/oracle/formula"
 	"price-feeder/oracle/provider"
+	"price-feeder/oracle/types"
 
 	"cosmossdk.io/math"
 	"github.com/BurntSushi/toml"
@@ -25,6 +28,10 @@ const (
 	defaultHeightPollInterval = 1 * time.Second
 	defaultHistoryDb          = "prices.db"
 	defaultDerivativePeriod   = 30 * time.Minute
+
+	defaultGenericRestPollInterval = 5 * time.Second
+	defaultUniswapV2PollInterval   = 15 * time.Second
+	defaultPluginPollInterval      = 5 * time.Second
 )
 
 var (
@@ -34,62 +41,31 @@ var (
 	ErrEmptyConfigPath = errors.New("empty configuration file path")
 
 	// SupportedProviders defines a lookup table of all the supported currency API
-	// providers.
-	SupportedProviders = map[provider.Name]struct{}{
-		provider.ProviderAstroportInjective: {},
-		provider.ProviderAstroportNeutron:   {},
-		provider.ProviderAstroportTerra2:    {},
-		provider.ProviderBinance:            {},
-		provider.ProviderBinanceUS:          {},
-		provider.ProviderBingx:              {},
-		provider.ProviderBitfinex:           {},
-		provider.ProviderBitforex:           {},
-		provider.ProviderBitget:             {},
-		provider.ProviderBitmart:            {},
-		provider.ProviderBitstamp:           {},
-		provider.ProviderBybit:              {},
-		provider.ProviderCamelotV2:          {},
-		provider.ProviderCamelotV3:          {},
-		provider.ProviderCoinbase:           {},
-		provider.ProviderCoinex:             {},
-		provider.ProviderCrypto:             {},
-		provider.ProviderCurve:              {},
-		provider.ProviderDexter:             {},
-		provider.ProviderFin:                {},
-		provider.ProviderFinV2:              {},
-		provider.ProviderGate:               {},
-		provider.ProviderHelix:              {},
-		provider.ProviderHitBtc:             {},
-		provider.ProviderHuobi:              {},
-		provider.ProviderIdxOsmosis:         {},
-		provider.ProviderKraken:             {},
-		provider.ProviderKucoin:             {},
-		provider.ProviderLbank:              {},
-		provider.ProviderMaya:               {},
-		provider.ProviderMexc:               {},
-		provider.ProviderMock:               {},
-		provider.ProviderOkx:                {},
-		provider.ProviderOsmosisV2:          {},
-		provider.ProviderPancakeV3Bsc:       {},
-		provider.ProviderPhemex:             {},
-		provider.ProviderPionex:             {},
-		provider.ProviderPoloniex:           {},
-		provider.ProviderPyth:               {},
-		provider.ProviderShade:              {},
-		provider.ProviderStride:             {},
-		provider.ProviderUniswapV3:          {},
-		provider.ProviderUnstake:            {},
-		provider.ProviderVelodromeV2:        {},
-		provider.ProviderWhitewhaleCmdx:     {},
-		provider.ProviderWhitewhaleHuahua:   {},
-		provider.ProviderWhitewhaleInj:      {},
-		provider.ProviderWhitewhaleJuno:     {},
-		provider.ProviderWhitewhaleLuna:     {},
-		provider.ProviderWhitewhaleLunc:     {},
-		provider.ProviderWhitewhaleSei:      {},
-		provider.ProviderWhitewhaleWhale:    {},
-		provider.ProviderXt:                 {},
-		provider.ProviderZero:               {},
+	// providers, as registered by the provider package.
+	SupportedProviders = supportedProviders()
+
+	// SupportedOrderBookProviders defines the providers that can price pairs
+	// from their order books instead of their last trades.
+	SupportedOrderBookProviders = map[provider.Name]struct{}{
+		provider.ProviderBinance:   {},
+		provider.ProviderBinanceUS: {},
+		provider.ProviderBybit:     {},
+		provider.ProviderCoinbase:  {},
+		provider.ProviderKraken:    {},
+		provider.ProviderKucoin:    {},
+		provider.ProviderOkx:       {},
+	}
+
+	// SupportedTradeProviders defines the providers that can price pairs by
+	// the VWAP of their recent trades.
+	SupportedTradeProviders = map[provider.Name]struct{}{
+		provider.ProviderBinance:   {},
+		provider.ProviderBinanceUS: {},
+		provider.ProviderBybit:     {},
+		provider.ProviderCoinbase:  {},
+		provider.ProviderKraken:    {},
+		provider.ProviderKucoin:    {},
+		provider.ProviderOkx:       {},
 	}
 
 	SupportedDerivatives = map[string]struct{}{
@@ -109,6 +85,13 @@ type (
 		Deviations           []Deviation                   `toml:"deviation_thresholds"`
 		ProviderMinOverrides []ProviderMinOverrides        `toml:"provider_min_overrides"`
 		ProviderWeights      map[string]map[string]float64 `toml:"provider_weight"`
+		LiquidityFilters     []LiquidityFilter             `toml:"liquidity_filters" validate:"dive"`
+		Pegs                 []Peg                         `toml:"pegs" validate:"dive"`
+		Synthetics           []Synthetic                   `toml:"synthetics" validate:"dive"`
+		Precisions           []Precision                   `toml:"precision" validate:"dive"`
+		GenericRest          []GenericRest                 `toml:"generic_rest" validate:"dive"`
+		UniswapV2            []UniswapV2                   `toml:"uniswapv2" validate:"dive"`
+		Plugins              []Plugin                      `toml:"plugin" validate:"dive"`
 		Account              Account                       `toml:"account" validate:"required,gt=0,dive,required"`
 		Keyring              Keyring                       `toml:"keyring" validate:"required,gt=0,dive,required"`
 		RPC                  RPC                           `toml:"rpc" validate:"required,gt=0,dive,required"`
@@ -131,11 +114,13 @@ type (
 
 	// Server defines the API server configuration.
 	Server struct {
-		ListenAddr     string   `toml:"listen_addr"`
-		WriteTimeout   string   `toml:"write_timeout"`
-		ReadTimeout    string   `toml:"read_timeout"`
-		VerboseCORS    bool     `toml:"verbose_cors"`
-		AllowedOrigins []string `toml:"allowed_origins"`
+		ListenAddr            string   `toml:"listen_addr"`
+		WriteTimeout          string   `toml:"write_timeout"`
+		ReadTimeout           string   `toml:"read_timeout"`
+		VerboseCORS           bool     `toml:"verbose_cors"`
+		AllowedOrigins        []string `toml:"allowed_origins"`
+		EnableReload          bool     `toml:"enable_reload"`           // allow reloading the config via the api
+		EnableProviderControl bool     `toml:"enable_provider_control"` // allow pausing and resuming providers via the api
 	}
 
 	// CurrencyPair defines a price quote of the exchange rate for two different
@@ -162,6 +147,43 @@ type (
 		Providers uint     `toml:"providers" validate:"required"`
 	}
 
+	// LiquidityFilter defines the minimum pool liquidity in USD that DEX
+	// providers need to report for the given denoms. Optionally the liquidity
+	// is used as weight instead of the volume, tickers without liquidity
+	// keep their USD volume.
+	LiquidityFilter struct {
+		Denoms            []string `toml:"denoms" validate:"required"`
+		MinTvl            string   `toml:"min_tvl"`
+		WeightByLiquidity bool     `toml:"weight_by_liquidity"`
+	}
+
+	// Peg defines an asset that is priced at a fixed rate of another asset,
+	// or at a constant USD value if quote is USD. Band defines the maximum
+	// relative deviation of market prices from the peg before alerting.
+	Peg struct {
+		Base  string `toml:"base" validate:"required"`
+		Quote string `toml:"quote" validate:"required"`
+		Rate  string `toml:"rate" validate:"required"`
+		Band  string `toml:"band"`
+	}
+
+	// Synthetic defines a denom whose price is computed by a formula over
+	// other prices, e.g. "0.6*BTC + 0.4*ETH".
+	Synthetic struct {
+		Denom   string `toml:"denom" validate:"required"`
+		Formula string `toml:"formula" validate:"required"`
+	}
+
+	// Precision defines the rounding of the prices of the given denoms,
+	// either to significant figures or to decimal places. Supported
+	// rounding modes are half_even (default), floor and ceil.
+	Precision struct {
+		Denoms             []string `toml:"denoms" validate:"required"`
+		SignificantFigures *int64   `toml:"significant_figures"`
+		DecimalPlaces      *int64   `toml:"decimal_places"`
+		Rounding           string   `toml:"rounding"`
+	}
+
 	// Account defines account related configuration that is related to the
 	// network and transaction signing functionality.
 	Account struct {
@@ -233,11 +255,110 @@ type (
 		VolumePause  int            `toml:"volume_pause"`
 		Decimals     map[string]int `toml:"decimals"`
 		Periods      map[string]int
+		// OrderBook prices pairs by the mid of the order book, or by the
+		// depth weighted price of OrderBookNotional (in quote) if set.
+		// MaxSpreads limits the relative spread per pair, ex.: ATOMUSDT.
+		OrderBook         bool              `toml:"order_book"`
+		OrderBookDepth    int               `toml:"order_book_depth"`
+		OrderBookNotional string            `toml:"order_book_notional"`
+		MaxSpreads        map[string]string `toml:"max_spread"`
+		// Trades prices pairs by the VWAP of the trades within TradeWindow.
+		Trades      bool   `toml:"trades"`
+		TradeWindow string `toml:"trade_window"`
+		// Twap selects the arithmetic or geometric twap of osmosistwap.
+		Twap string `toml:"twap"`
+		// Heartbeats are the maximum ages of chainlink rounds per pair,
+		// ex.: ETHUSD = "1h".
+		Heartbeats map[string]string `toml:"heartbeats"`
+		// TwapWindows are the osmosistwap windows per pair, ex.:
+		// ATOMOSMO = "10m".
+		TwapWindows map[string]string `toml:"twap_windows"`
+		// MaxAges are the maximum ages of stride redemption rates per pair,
+		// ex.: STATOMATOM = "12h".
+		MaxAges map[string]string `toml:"max_ages"`
+		// RateLimit overrides the request budget of the provider's hosts.
+		RateLimit *RateLimit `toml:"rate_limit"`
+		// Hedge races the two best urls, sending the request to the second
+		// one if the first has not answered after this delay.
+		Hedge string `toml:"hedge"`
+		// MaxHeightLag demotes cosmos and evm urls serving heights this many
+		// blocks behind the highest url.
+		MaxHeightLag uint64 `toml:"max_height_lag"`
+		// Proxy is an http, https or socks5 url for rest and websockets.
+		Proxy   string            `toml:"proxy"`
+		Headers map[string]string `toml:"headers"`
+		// ApiKey is sent in the ApiKeyHeader and/or the ApiKeyParam query
+		// parameter. Environment variables are expanded in api keys and
+		// headers, ex.: "${ALCHEMY_API_KEY}".
+		ApiKey       string `toml:"api_key"`
+		ApiKeyHeader string `toml:"api_key_header"`
+		ApiKeyParam  string `toml:"api_key_param"`
+		TLS          *TLS   `toml:"tls"`
+	}
+
+	// TLS defines a custom CA, a client certificate and the server name to
+	// verify for the endpoints of a provider.
+	TLS struct {
+		CAFile             string `toml:"ca_file"`
+		CertFile           string `toml:"cert_file"`
+		KeyFile            string `toml:"key_file"`
+		ServerName         string `toml:"server_name"`
+		InsecureSkipVerify bool   `toml:"insecure_skip_verify"`
+	}
+
+	// RateLimit defines a token bucket of Requests per Interval, with
+	// request weights per path prefix, and a cooldown once the used weight
+	// reported in WeightHeader nears MaxWeight, ex.: X-MBX-USED-WEIGHT-1M.
+	RateLimit struct {
+		Requests       int            `toml:"requests"`
+		Interval       string         `toml:"interval"`
+		Weights        map[string]int `toml:"weights"`
+		WeightHeader   string         `toml:"weight_header"`
+		MaxWeight      int            `toml:"max_weight"`
+		WeightInterval string         `toml:"weight_interval"`
 	}
 
 	UrlSet struct {
 		Urls []string `toml:"urls"`
 	}
+
+	// GenericRest defines a provider that polls a JSON REST API and
+	// extracts the tickers with dot separated paths, ex.: "data.0.last".
+	GenericRest struct {
+		Name            provider.Name `toml:"name" validate:"required"`
+		Urls            []string      `toml:"urls" validate:"required,gt=0"`
+		Path            string        `toml:"path" validate:"required"`
+		Symbol          string        `toml:"symbol"`
+		Tickers         string        `toml:"tickers"`
+		SymbolField     string        `toml:"symbol_field"`
+		Price           string        `toml:"price" validate:"required"`
+		Volume          string        `toml:"volume"`
+		VolumeUnit      string        `toml:"volume_unit"`
+		Timestamp       string        `toml:"timestamp"`
+		TimestampFormat string        `toml:"timestamp_format"`
+		PollInterval    string        `toml:"poll_interval"`
+	}
+
+	// UniswapV2 defines a named uniswap v2 style provider, e.g. a fork on
+	// any evm chain. Pair contracts are set in contract_addresses.<name>
+	// and keyed by token0 and token1 of the pair.
+	UniswapV2 struct {
+		Name         provider.Name `toml:"name" validate:"required"`
+		Urls         []string      `toml:"urls" validate:"required,gt=0"`
+		Chain        string        `toml:"chain"`
+		PollInterval string        `toml:"poll_interval"`
+		VolumeBlocks int           `toml:"volume_blocks"`
+	}
+
+	// Plugin defines a named out-of-process provider that is either
+	// launched with Command or reached at the tcp Address. The protocol is
+	// documented in pkg/plugin.
+	Plugin struct {
+		Name         provider.Name `toml:"name" validate:"required"`
+		Command      []string      `toml:"command"`
+		Address      string        `toml:"address"`
+		PollInterval string        `toml:"poll_interval"`
+	}
 )
 
 // telemetryValidation is custom validation for the Telemetry struct.
@@ -264,6 +385,24 @@ func endpointValidation(sl validator.StructLevel) {
 	if _, ok := SupportedProviders[endpoint.Name]; !ok {
 		sl.ReportError(endpoint.Name, "name", "Name", "unsupportedEndpointProvider", "")
 	}
+
+	registration, found := provider.Lookup(endpoint.Name)
+	if found && endpoint.Websocket != "" && !registration.Websocket {
+		sl.ReportError(endpoint.Websocket, "websocket", "Websocket", "unsupportedWebsocket", "")
+	}
+}
+
+// supportedProviders returns the registered providers that can be named in
+// the config, custom providers are only configured in their own sections.
+func supportedProviders() map[provider.Name]struct{} {
+	providers := map[provider.Name]struct{}{}
+	for _, registration := range provider.Registered() {
+		if registration.Custom {
+			continue
+		}
+		providers[registration.Name] = struct{}{}
+	}
+	return providers
 }
 
 // Validate returns an error if the Config object is invalid.
@@ -306,6 +445,365 @@ func (p ProviderEndpoints) ToEndpoint(
 		VolumePause:   p.VolumePause,
 		Decimals:      p.Decimals,
 		Periods:       p.Periods,
+		Twap:          p.Twap,
+		MaxHeightLag:  p.MaxHeightLag,
+	}
+
+	if p.Hedge != "" {
+		hedge, err := time.ParseDuration(p.Hedge)
+		if err != nil {
+			return provider.Endpoint{}, fmt.Errorf("failed to parse hedge: %v", err)
+		}
+		if hedge <= 0 {
+			return provider.Endpoint{}, fmt.Errorf("hedge must be positive")
+		}
+		e.Hedge = hedge
+	}
+
+	if len(p.Heartbeats) > 0 {
+		heartbeats, err := parseDurations("heartbeats", p.Heartbeats)
+		if err != nil {
+			return provider.Endpoint{}, err
+		}
+		e.Heartbeats = heartbeats
+	}
+
+	if len(p.TwapWindows) > 0 {
+		windows, err := parseDurations("twap_windows", p.TwapWindows)
+		if err != nil {
+			return provider.Endpoint{}, err
+		}
+		e.TwapWindows = windows
+	}
+
+	if len(p.MaxAges) > 0 {
+		maxAges, err := parseDurations("max_ages", p.MaxAges)
+		if err != nil {
+			return provider.Endpoint{}, err
+		}
+		e.MaxAges = maxAges
+	}
+
+	if p.OrderBook {
+		options, err := p.orderBookOptions()
+		if err != nil {
+			return provider.Endpoint{}, err
+		}
+		e.OrderBook = options
+	}
+
+	if p.Trades {
+		options, err := p.tradeOptions()
+		if err != nil {
+			return provider.Endpoint{}, err
+		}
+		e.Trades = options
+	}
+
+	if p.RateLimit != nil {
+		options, err := p.RateLimit.toOptions()
+		if err != nil {
+			return provider.Endpoint{}, err
+		}
+		e.RateLimit = options
+	}
+
+	if p.Proxy != "" || len(p.Headers) > 0 || p.ApiKey != "" || p.TLS != nil {
+		options, err := p.httpOptions()
+		if err != nil {
+			return provider.Endpoint{}, err
+		}
+		e.Http = options
+	}
+
+	return e, nil
+}
+
+func (p ProviderEndpoints) httpOptions() (*provider.HttpOptions, error) {
+	options := &provider.HttpOptions{
+		Proxy:        p.Proxy,
+		Headers:      make(map[string]string, len(p.Headers)),
+		ApiKeyHeader: p.ApiKeyHeader,
+		ApiKeyParam:  p.ApiKeyParam,
+	}
+
+	for key, value := range p.Headers {
+		expanded, err := expandEnv(value)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", key, err)
+		}
+		options.Headers[key] = expanded
+	}
+
+	if p.ApiKey != "" {
+		if p.ApiKeyHeader == "" && p.ApiKeyParam == "" {
+			return nil, fmt.Errorf("api_key of %s needs an api_key_header or api_key_param", p.Name)
+		}
+		apiKey, err := expandEnv(p.ApiKey)
+		if err != nil {
+			return nil, fmt.Errorf("api_key: %w", err)
+		}
+		options.ApiKey = apiKey
+	}
+
+	if p.TLS != nil {
+		options.TLS = &provider.TLSOptions{
+			CAFile:             p.TLS.CAFile,
+			CertFile:           p.TLS.CertFile,
+			KeyFile:            p.TLS.KeyFile,
+			ServerName:         p.TLS.ServerName,
+			InsecureSkipVerify: p.TLS.InsecureSkipVerify,
+		}
+	}
+
+	// fail on startup rather than on the first request
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// expandEnv replaces ${VAR} and $VAR with the value of the environment
+// variable, unset variables are an error.
+func expandEnv(value string) (string, error) {
+	var missing []string
+	expanded := os.Expand(value, func(name string) string {
+		env, found := os.LookupEnv(name)
+		if !found {
+			missing = append(missing, name)
+		}
+		return env
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable not set: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+func (r RateLimit) toOptions() (*provider.RateLimitOptions, error) {
+	if r.Requests < 0 || r.MaxWeight < 0 {
+		return nil, fmt.Errorf("rate_limit requests and max_weight must not be negative")
+	}
+
+	options := &provider.RateLimitOptions{
+		Requests:     r.Requests,
+		Weights:      r.Weights,
+		WeightHeader: r.WeightHeader,
+		MaxWeight:    r.MaxWeight,
+	}
+
+	if r.Requests > 0 {
+		if r.Interval == "" {
+			return nil, fmt.Errorf("rate_limit requests need an interval")
+		}
+		interval, err := time.ParseDuration(r.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rate_limit interval: %v", err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("rate_limit interval must be positive")
+		}
+		options.Interval = interval
+	}
+
+	if r.WeightInterval != "" {
+		interval, err := time.ParseDuration(r.WeightInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rate_limit weight_interval: %v", err)
+		}
+		options.WeightInterval = interval
+	}
+
+	for path, weight := range r.Weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("rate_limit weight of %s must not be negative", path)
+		}
+	}
+
+	return options, nil
+}
+
+func (p ProviderEndpoints) tradeOptions() (*provider.TradeOptions, error) {
+	if _, ok := SupportedTradeProviders[p.Name]; !ok {
+		return nil, fmt.Errorf("trades not supported by %s", p.Name)
+	}
+
+	if p.OrderBook {
+		return nil, fmt.Errorf("cannot combine order_book and trades for %s", p.Name)
+	}
+
+	options := &provider.TradeOptions{}
+
+	if p.TradeWindow != "" {
+		window, err := time.ParseDuration(p.TradeWindow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse trade window: %v", err)
+		}
+		if window <= 0 {
+			return nil, fmt.Errorf("trade_window must be positive")
+		}
+		options.Window = window
+	}
+
+	return options, nil
+}
+
+func (p ProviderEndpoints) orderBookOptions() (*provider.OrderBookOptions, error) {
+	if _, ok := SupportedOrderBookProviders[p.Name]; !ok {
+		return nil, fmt.Errorf("order book not supported by %s", p.Name)
+	}
+
+	if p.OrderBookDepth < 0 {
+		return nil, fmt.Errorf("order_book_depth must not be negative")
+	}
+
+	options := &provider.OrderBookOptions{
+		Depth:      p.OrderBookDepth,
+		MaxSpreads: make(map[string]math.LegacyDec, len(p.MaxSpreads)),
+	}
+
+	if p.OrderBookNotional != "" {
+		notional, err := math.LegacyNewDecFromStr(p.OrderBookNotional)
+		if err != nil {
+			return nil, fmt.Errorf("order_book_notional must be numeric: %w", err)
+		}
+		if notional.IsNegative() {
+			return nil, fmt.Errorf("order_book_notional must not be negative")
+		}
+		options.Notional = notional
+	}
+
+	for pair, value := range p.MaxSpreads {
+		spread, err := math.LegacyNewDecFromStr(value)
+		if err != nil {
+			return nil, fmt.Errorf("max_spread must be numeric: %w", err)
+		}
+		if !spread.IsPositive() {
+			return nil, fmt.Errorf("max_spread must be positive")
+		}
+		options.MaxSpreads[strings.ToUpper(pair)] = spread
+	}
+
+	return options, nil
+}
+
+// parseDurations parses durations per pair, ex.: the chainlink heartbeats.
+func parseDurations(name string, values map[string]string) (map[string]time.Duration, error) {
+	durations := make(map[string]time.Duration, len(values))
+	for pair, value := range values {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", name, err)
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("%s must be positive", name)
+		}
+		durations[strings.ToUpper(pair)] = duration
+	}
+	return durations, nil
+}
+
+func (g GenericRest) ToEndpoint() (provider.Endpoint, error) {
+	pollInterval := defaultGenericRestPollInterval
+	if g.PollInterval != "" {
+		interval, err := time.ParseDuration(g.PollInterval)
+		if err != nil {
+			return provider.Endpoint{}, fmt.Errorf("failed to parse poll interval: %v", err)
+		}
+		pollInterval = interval
+	}
+
+	var volumeUnit types.VolumeUnit
+	switch g.VolumeUnit {
+	case "", "base":
+		volumeUnit = types.VolumeUnitBase
+	case "quote":
+		volumeUnit = types.VolumeUnitQuote
+	case "usd":
+		volumeUnit = types.VolumeUnitUSD
+	default:
+		return provider.Endpoint{}, fmt.Errorf("unsupported volume unit: %s", g.VolumeUnit)
+	}
+
+	switch g.TimestampFormat {
+	case "",
+		provider.GenericRestTimestampUnix,
+		provider.GenericRestTimestampUnixMs,
+		provider.GenericRestTimestampRFC3339:
+	default:
+		return provider.Endpoint{}, fmt.Errorf("unsupported timestamp format: %s", g.TimestampFormat)
+	}
+
+	e := provider.Endpoint{
+		Name:         g.Name,
+		Urls:         g.Urls,
+		PollInterval: pollInterval,
+		Generic: &provider.GenericRestOptions{
+			Path:            g.Path,
+			Symbol:          g.Symbol,
+			Tickers:         g.Tickers,
+			SymbolField:     g.SymbolField,
+			Price:           g.Price,
+			Volume:          g.Volume,
+			VolumeUnit:      volumeUnit,
+			Timestamp:       g.Timestamp,
+			TimestampFormat: g.TimestampFormat,
+		},
+	}
+	return e, nil
+}
+
+func (u UniswapV2) ToEndpoint() (provider.Endpoint, error) {
+	pollInterval := defaultUniswapV2PollInterval
+	if u.PollInterval != "" {
+		interval, err := time.ParseDuration(u.PollInterval)
+		if err != nil {
+			return provider.Endpoint{}, fmt.Errorf("failed to parse poll interval: %v", err)
+		}
+		pollInterval = interval
+	}
+
+	e := provider.Endpoint{
+		Name:         u.Name,
+		Type:         provider.ProviderUniswapV2,
+		Chain:        u.Chain,
+		Urls:         u.Urls,
+		PollInterval: pollInterval,
+		VolumeBlocks: u.VolumeBlocks,
+	}
+	return e, nil
+}
+
+func (p Plugin) ToEndpoint() (provider.Endpoint, error) {
+	if (len(p.Command) == 0) == (p.Address == "") {
+		return provider.Endpoint{}, fmt.Errorf("plugin %s needs either a command or an address", p.Name)
+	}
+
+	pollInterval := defaultPluginPollInterval
+	if p.PollInterval != "" {
+		interval, err := time.ParseDuration(p.PollInterval)
+		if err != nil {
+			return provider.Endpoint{}, fmt.Errorf("failed to parse poll interval: %v", err)
+		}
+		pollInterval = interval
+	}
+
+	// the url is only informational, plugins are reached by their options
+	url := "tcp://" + p.Address
+	if len(p.Command) > 0 {
+		url = "exec://" + p.Command[0]
+	}
+
+	e := provider.Endpoint{
+		Name:         p.Name,
+		Type:         provider.ProviderPlugin,
+		Urls:         []string{url},
+		PollInterval: pollInterval,
+		Plugin: &provider.PluginOptions{
+			Command: p.Command,
+			Address: p.Address,
+		},
 	}
 	return e, nil
 }
@@ -347,6 +845,59 @@ func ParseConfig(configPath string) (Config, error) {
 		cfg.HistoryDb = defaultHistoryDb
 	}
 
+	// pegs are matched against each other and against the upper case denoms
+	// of the computed prices
+	for i := range cfg.Pegs {
+		cfg.Pegs[i].Base = strings.ToUpper(cfg.Pegs[i].Base)
+		cfg.Pegs[i].Quote = strings.ToUpper(cfg.Pegs[i].Quote)
+	}
+
+	// precisions are looked up by the upper case denoms of the prices
+	for i := range cfg.Precisions {
+		for j, denom := range cfg.Precisions[i].Denoms {
+			cfg.Precisions[i].Denoms[j] = strings.ToUpper(denom)
+		}
+	}
+
+	// named providers of generic_rest, uniswapv2 and plugin sections
+	customProviders := map[provider.Name]struct{}{}
+	for _, generic := range cfg.GenericRest {
+		if _, ok := SupportedProviders[generic.Name]; ok {
+			return cfg, fmt.Errorf("generic_rest name is a supported provider: %s", generic.Name)
+		}
+		if _, ok := customProviders[generic.Name]; ok {
+			return cfg, fmt.Errorf("duplicate generic_rest name: %s", generic.Name)
+		}
+		if _, err := generic.ToEndpoint(); err != nil {
+			return cfg, err
+		}
+		customProviders[generic.Name] = struct{}{}
+	}
+	for _, uniswap := range cfg.UniswapV2 {
+		if _, ok := SupportedProviders[uniswap.Name]; ok {
+			return cfg, fmt.Errorf("uniswapv2 name is a supported provider: %s", uniswap.Name)
+		}
+		if _, ok := customProviders[uniswap.Name]; ok {
+			return cfg, fmt.Errorf("duplicate uniswapv2 name: %s", uniswap.Name)
+		}
+		if _, err := uniswap.ToEndpoint(); err != nil {
+			return cfg, err
+		}
+		customProviders[uniswap.Name] = struct{}{}
+	}
+	for _, plugin := range cfg.Plugins {
+		if _, ok := SupportedProviders[plugin.Name]; ok {
+			return cfg, fmt.Errorf("plugin name is a supported provider: %s", plugin.Name)
+		}
+		if _, ok := customProviders[plugin.Name]; ok {
+			return cfg, fmt.Errorf("duplicate plugin name: %s", plugin.Name)
+		}
+		if _, err := plugin.ToEndpoint(); err != nil {
+			return cfg, err
+		}
+		customProviders[plugin.Name] = struct{}{}
+	}
+
 	derivativeDenoms := map[string]struct{}{}
 	derivativeBases := map[string]struct{}{}
 	pairs := make(map[string]map[provider.Name]struct{})
@@ -380,7 +931,9 @@ func ParseConfig(configPath string) (Config, error) {
 			}
 		}
 		for _, provider := range cp.Providers {
-			if _, ok := SupportedProviders[provider]; !ok {
+			_, supported := SupportedProviders[provider]
+			_, custom := customProviders[provider]
+			if !supported && !custom {
 				return cfg, fmt.Errorf("unsupported provider: %s", provider)
 			}
 			pairs[cp.Base][provider] = struct{}{}
@@ -398,6 +951,140 @@ func ParseConfig(configPath string) (Config, error) {
 		}
 	}
 
+	for _, filter := range cfg.LiquidityFilters {
+		if filter.MinTvl == "" {
+			continue
+		}
+
+		minTvl, err := math.LegacyNewDecFromStr(filter.MinTvl)
+		if err != nil {
+			return cfg, fmt.Errorf("min_tvl must be numeric: %w", err)
+		}
+
+		if minTvl.IsNegative() {
+			return cfg, fmt.Errorf("min_tvl must not be negative")
+		}
+	}
+
+	pegs := map[string]string{}
+	for _, peg := range cfg.Pegs {
+		if _, ok := pegs[peg.Base]; ok {
+			return cfg, fmt.Errorf("duplicate peg for %s", peg.Base)
+		}
+		if _, ok := derivativeBases[peg.Base]; ok {
+			return cfg, fmt.Errorf("cannot combine derivative and peg for %s", peg.Base)
+		}
+		pegs[peg.Base] = peg.Quote
+
+		rate, err := math.LegacyNewDecFromStr(peg.Rate)
+		if err != nil {
+			return cfg, fmt.Errorf("peg rate must be numeric: %w", err)
+		}
+		if !rate.IsPositive() {
+			return cfg, fmt.Errorf("peg rate must be positive")
+		}
+
+		if peg.Band != "" {
+			band, err := math.LegacyNewDecFromStr(peg.Band)
+			if err != nil {
+				return cfg, fmt.Errorf("peg band must be numeric: %w", err)
+			}
+			if band.IsNegative() {
+				return cfg, fmt.Errorf("peg band must not be negative")
+			}
+		}
+	}
+
+	for base := range pegs {
+		visited := map[string]struct{}{base: {}}
+		quote, ok := pegs[base]
+		for ok {
+			if _, found := visited[quote]; found {
+				return cfg, fmt.Errorf("circular peg for %s", base)
+			}
+			visited[quote] = struct{}{}
+			quote, ok = pegs[quote]
+		}
+	}
+
+	formulas := map[string]formula.Expr{}
+	for _, synthetic := range cfg.Synthetics {
+		denom := strings.ToUpper(synthetic.Denom)
+		if _, ok := formulas[denom]; ok {
+			return cfg, fmt.Errorf("duplicate synthetic for %s", denom)
+		}
+		if _, ok := pegs[denom]; ok {
+			return cfg, fmt.Errorf("cannot combine peg and synthetic for %s", denom)
+		}
+		if _, ok := pairs[denom]; ok {
+			return cfg, fmt.Errorf("cannot combine currency pair and synthetic for %s", denom)
+		}
+
+		expr, err := formula.Parse(synthetic.Formula)
+		if err != nil {
+			return cfg, err
+		}
+		formulas[denom] = expr
+	}
+
+	if _, err := formula.Order(formulas); err != nil {
+		return cfg, err
+	}
+
+	precisions := map[string]struct{}{}
+	for _, precision := range cfg.Precisions {
+		for _, denom := range precision.Denoms {
+			if _, ok := precisions[denom]; ok {
+				return cfg, fmt.Errorf("duplicate precision for %s", denom)
+			}
+			precisions[denom] = struct{}{}
+		}
+		if (precision.SignificantFigures == nil) == (precision.DecimalPlaces == nil) {
+			return cfg, fmt.Errorf("precision requires either significant_figures or decimal_places")
+		}
+		if precision.SignificantFigures != nil &&
+			(*precision.SignificantFigures < 1 || *precision.SignificantFigures > math.LegacyPrecision) {
+			return cfg, fmt.Errorf("significant_figures must be between 1 and %d", math.LegacyPrecision)
+		}
+		if precision.DecimalPlaces != nil &&
+			(*precision.DecimalPlaces < 0 || *precision.DecimalPlaces > math.LegacyPrecision) {
+			return cfg, fmt.Errorf("decimal_places must be between 0 and %d", math.LegacyPrecision)
+		}
+		switch strings.ToLower(precision.Rounding) {
+		case "", "half_even", "floor", "ceil":
+		default:
+			return cfg, fmt.Errorf("unsupported rounding mode: %s", precision.Rounding)
+		}
+	}
+
+	// the on-chain mode of curve calls the configured pools on an evm rpc
+	// instead of the default curve.fi api
+	curveOnChain := len(cfg.ContractAdresses[provider.ProviderCurve.String()]) > 0
+	for _, endpoint := range cfg.ProviderEndpoints {
+		if endpoint.Name == provider.ProviderCurve && len(endpoint.Urls) > 0 {
+			curveOnChain = false
+		}
+		if endpoint.OrderBook {
+			if _, err := endpoint.orderBookOptions(); err != nil {
+				return cfg, err
+			}
+		}
+		if endpoint.Trades {
+			if _, err := endpoint.tradeOptions(); err != nil {
+				return cfg, err
+			}
+		}
+		switch endpoint.Twap {
+		case "", provider.OsmosisTwapArithmetic, provider.OsmosisTwapGeometric:
+		default:
+			return cfg, fmt.Errorf("unsupported twap for %s: %s", endpoint.Name, endpoint.Twap)
+		}
+	}
+
+	if curveOnChain {
+		return cfg, fmt.Errorf("contract_addresses.curve requires evm rpc urls in provider_endpoints")
+	}
+
 	for _, override := range cfg.ProviderMinOverrides {
 		if override.Providers < 1 {
 			return cfg, fmt.Errorf("minimum providers must be greater than 0")
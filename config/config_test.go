@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"price-feeder/config"
 	"price-feeder/oracle/provider"
@@ -551,3 +552,152 @@ global_labels = [["chain-id", "umee-local-testnet"]]
 	_, err = config.ParseConfig(tmpFile.Name())
 	require.Error(t, err)
 }
+
+func TestParseConfig_Precisions(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "price-feeder.toml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	content := `
+gas_adjustment = 1.5
+gas_prices = "0.00125ukuji"
+
+[server]
+listen_addr = "0.0.0.0:99999"
+read_timeout = "20s"
+verbose_cors = true
+write_timeout = "20s"
+
+[[precision]]
+denoms = ["atom"]
+significant_figures = 6
+
+[[currency_pairs]]
+base = "ATOM"
+quote = "USD"
+providers = [
+	"kraken",
+	"binance",
+	"huobi"
+]
+
+[account]
+address = "kujira15nejfgcaanqpw25ru4arvfd0fwy6j8clccvwx4"
+validator = "kujiravalcons14rjlkfzp56733j5l5nfk6fphjxymgf8mj04d5p"
+chain_id = "kujira-local-testnet"
+prefix = "kujira"
+
+[keyring]
+backend = "test"
+dir = "/Users/username/.kujira"
+pass = "keyringPassword"
+
+[rpc]
+tmrpc_endpoint = "http://localhost:26657"
+grpc_endpoint = "localhost:9090"
+rpc_timeout = "100ms"
+`
+	_, err = tmpFile.Write([]byte(content))
+	require.NoError(t, err)
+
+	cfg, err := config.ParseConfig(tmpFile.Name())
+	require.NoError(t, err)
+	require.Equal(t, []string{"ATOM"}, cfg.Precisions[0].Denoms)
+
+	// the same denom in different cases is a duplicate
+	content += `
+[[precision]]
+denoms = ["ATOM"]
+decimal_places = 2
+`
+	require.NoError(t, os.WriteFile(tmpFile.Name(), []byte(content), 0o600))
+
+	_, err = config.ParseConfig(tmpFile.Name())
+	require.ErrorContains(t, err, "duplicate precision for ATOM")
+}
+
+func TestParseConfig_Invalid_Pegs(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "price-feeder.toml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	content := []byte(`
+gas_adjustment = 1.5
+gas_prices = "0.00125ukuji"
+
+[server]
+listen_addr = "0.0.0.0:99999"
+read_timeout = "20s"
+verbose_cors = true
+write_timeout = "20s"
+
+[[pegs]]
+base = "AXLUSDC"
+quote = "USDC"
+rate = "1"
+
+[[pegs]]
+base = "usdc"
+quote = "axlUSDC"
+rate = "1"
+
+[[currency_pairs]]
+base = "ATOM"
+quote = "USD"
+providers = [
+	"kraken",
+	"binance",
+	"huobi"
+]
+
+[account]
+address = "kujira15nejfgcaanqpw25ru4arvfd0fwy6j8clccvwx4"
+validator = "kujiravalcons14rjlkfzp56733j5l5nfk6fphjxymgf8mj04d5p"
+chain_id = "kujira-local-testnet"
+prefix = "kujira"
+
+[keyring]
+backend = "test"
+dir = "/Users/username/.kujira"
+pass = "keyringPassword"
+
+[rpc]
+tmrpc_endpoint = "http://localhost:26657"
+grpc_endpoint = "localhost:9090"
+rpc_timeout = "100ms"
+`)
+	_, err = tmpFile.Write(content)
+	require.NoError(t, err)
+
+	_, err = config.ParseConfig(tmpFile.Name())
+	require.ErrorContains(t, err, "circular peg")
+}
+
+func TestProviderEndpoints_ToEndpoint_Durations(t *testing.T) {
+	endpoints := config.ProviderEndpoints{
+		Name:       provider.ProviderChainlink,
+		Urls:       []string{"http://localhost"},
+		Heartbeats: map[string]string{"ethusd": "1h"},
+	}
+
+	endpoint, err := endpoints.ToEndpoint(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]time.Duration{"ETHUSD": time.Hour}, endpoint.Heartbeats)
+
+	endpoints.Heartbeats = map[string]string{"ETHUSD": "0s"}
+	_, err = endpoints.ToEndpoint(nil)
+	require.ErrorContains(t, err, "heartbeats must be positive")
+
+	endpoints = config.ProviderEndpoints{
+		Name:    provider.ProviderStride,
+		Urls:    []string{"http://localhost"},
+		MaxAges: map[string]string{"statomatom": "12h"},
+	}
+	endpoint, err = endpoints.ToEndpoint(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]time.Duration{"STATOMATOM": 12 * time.Hour}, endpoint.MaxAges)
+
+	endpoints.MaxAges = map[string]string{"STATOMATOM": "twelve hours"}
+	_, err = endpoints.ToEndpoint(nil)
+	require.ErrorContains(t, err, "max_ages")
+}
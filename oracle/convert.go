@@ -14,6 +14,9 @@ import (
 // using the conversion rates of other tickers. It will also filter out any tickers
 // not within the deviation threshold set by the config.
 //
+// Volumes are normalized to a USD notional before they are used as weights,
+// so that base, quote and USD denominated volumes can be mixed.
+//
 // Ref: https://github.com/umee-network/umee/blob/4348c3e433df8c37dd98a690e96fc275de609bc1/price-feeder/oracle/filter.go#L41
 func convertTickersToUSD(
 	logger zerolog.Logger,
@@ -22,6 +25,7 @@ func convertTickersToUSD(
 	deviationThresholds map[string]math.LegacyDec,
 	providerMinOverrides map[string]int,
 	providerWeights map[string]ProviderWeight,
+	liquidityFilters map[string]LiquidityFilter,
 ) (map[string]math.LegacyDec, error) {
 	if len(providerPrices) == 0 {
 		return nil, nil
@@ -116,7 +120,13 @@ func convertTickersToUSD(
 
 			if quote == "USD" {
 				for providerName, tickerPrice := range tickerPrices {
-					newRates[providerName] = tickerPrice
+					newRates[providerName] = types.TickerPrice{
+						Price:      tickerPrice.Price,
+						Volume:     tickerPrice.USDVolume(math.LegacyOneDec()),
+						VolumeUnit: types.VolumeUnitUSD,
+						Liquidity:  tickerPrice.Liquidity,
+						Time:       tickerPrice.Time,
+					}
 				}
 			} else {
 				minProviders, found := providerMinOverrides[quote]
@@ -148,13 +158,20 @@ func convertTickersToUSD(
 
 				for providerName, tickerPrice := range tickerPrices {
 					newRates[providerName] = types.TickerPrice{
-						Price:  tickerPrice.Price.Mul(rate),
-						Volume: tickerPrice.Volume,
-						Time:   tickerPrice.Time,
+						Price:      tickerPrice.Price.Mul(rate),
+						Volume:     tickerPrice.USDVolume(rate),
+						VolumeUnit: types.VolumeUnitUSD,
+						Liquidity:  tickerPrice.USDLiquidity(rate),
+						Time:       tickerPrice.Time,
 					}
 				}
 			}
 
+			filter, found := liquidityFilters[base]
+			if found {
+				newRates = FilterTickerLiquidity(logger, symbol, newRates, filter)
+			}
+
 			if len(newRates) > 0 {
 				newRates, err := addRates(
 					logger,
@@ -82,6 +82,7 @@ func TestConvertTickersToUsdChaining(t *testing.T) {
 		make(map[string]math.LegacyDec),
 		providerMinOverrides,
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -166,15 +167,16 @@ func TestConvertTickersToUSDFiltering(t *testing.T) {
 		make(map[string]math.LegacyDec),
 		prividerMinOverrides,
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
-	// skip BTC/USDT from Coinbase
-	// (30000*10+30010*10+30020*100) / 120 = 30017.5
+	// skip BTC/USDT from Coinbase, weights are USD notional (price * volume)
+	// (30000*300000+30010*300100+30020*3002000) / 3602100 = 30017.501179867299630771
 
 	require.Equal(
 		t,
-		math.LegacyNewDecFromStr("30017.5"),
+		math.LegacyMustNewDecFromStr("30017.501179867299630771"),
 		rates["BTC"],
 	)
 }
@@ -230,15 +232,16 @@ func TestConvertTickersToUsdVwap(t *testing.T) {
 		make(map[string]math.LegacyDec),
 		providerMinOverrides,
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
-	// VWAP( BTCUSDT * USDTUSD, BTCUSD )
-	// ((30000*0.999*55*+30050*45) / 100 = 30006.0
+	// VWAP( BTCUSDT * USDTUSD, BTCUSD ) weighted by USD notional
+	// (29970*29970*55+30050*30050*45) / (29970*55+30050*45) = 30006.052789442111577684
 
 	require.Equal(
 		t,
-		math.LegacyNewDecFromStr("30006.0"),
+		math.LegacyMustNewDecFromStr("30006.052789442111577684"),
 		rates["BTC"],
 	)
 
@@ -270,6 +273,7 @@ func TestConvertTickersToUsdEmptyProvider(t *testing.T) {
 		make(map[string]math.LegacyDec),
 		make(map[string]int),
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -288,8 +292,156 @@ func TestConvertTickersToUsdEmptyPrices(t *testing.T) {
 		make(map[string]math.LegacyDec),
 		make(map[string]int),
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
 	require.Equal(t, 0, len(rates))
 }
+
+func TestConvertTickersToUsdMixedVolumeUnits(t *testing.T) {
+	providerPrices := provider.AggregatedProviderPrices{}
+
+	// CEX reporting base volume
+	providerPrices[provider.ProviderBinance] = map[string]types.TickerPrice{
+		"BTCUSD": {
+			Price:  math.LegacyMustNewDecFromStr("30000"),
+			Volume: math.LegacyMustNewDecFromStr("10"),
+		},
+		"ETHBTC": {
+			Price:  math.LegacyMustNewDecFromStr("0.05"),
+			Volume: math.LegacyMustNewDecFromStr("100"),
+		},
+	}
+
+	// DEX reporting quote volume
+	providerPrices[provider.ProviderUniswapV3] = map[string]types.TickerPrice{
+		"BTCUSD": {
+			Price:      math.LegacyMustNewDecFromStr("30100"),
+			Volume:     math.LegacyMustNewDecFromStr("100000"),
+			VolumeUnit: types.VolumeUnitQuote,
+		},
+		"ETHBTC": {
+			Price:      math.LegacyMustNewDecFromStr("0.06"),
+			Volume:     math.LegacyMustNewDecFromStr("5"),
+			VolumeUnit: types.VolumeUnitQuote,
+		},
+	}
+
+	// provider reporting USD notional
+	providerPrices[provider.ProviderPyth] = map[string]types.TickerPrice{
+		"BTCUSD": {
+			Price:      math.LegacyMustNewDecFromStr("29900"),
+			Volume:     math.LegacyMustNewDecFromStr("600000"),
+			VolumeUnit: types.VolumeUnitUSD,
+		},
+	}
+
+	btcUsd := types.CurrencyPair{Base: "BTC", Quote: "USD"}
+	ethBtc := types.CurrencyPair{Base: "ETH", Quote: "BTC"}
+
+	providerPairs := map[provider.Name][]types.CurrencyPair{
+		provider.ProviderBinance:   {btcUsd, ethBtc},
+		provider.ProviderUniswapV3: {btcUsd, ethBtc},
+		provider.ProviderPyth:      {btcUsd},
+	}
+
+	deviations := map[string]math.LegacyDec{
+		"BTC": math.LegacyMustNewDecFromStr("2"),
+	}
+
+	providerMinOverrides := map[string]int{
+		"BTC": 1,
+		"ETH": 1,
+	}
+
+	rates, err := convertTickersToUSD(
+		zerolog.Nop(),
+		providerPrices,
+		providerPairs,
+		deviations,
+		providerMinOverrides,
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	// (30000*300000 + 30100*100000 + 29900*600000) / 1000000 = 29950
+	require.Equal(t, math.LegacyMustNewDecFromStr("29950"), rates["BTC"])
+
+	// ETH is converted with BTC = 29950
+	// binance: 0.05*29950 = 1497.5 with 100*1497.5 = 149750 USD volume
+	// uniswap: 0.06*29950 = 1797 with 5*29950 = 149750 USD volume
+	// (1497.5*149750 + 1797*149750) / 299500 = 1647.25
+	require.Equal(t, math.LegacyMustNewDecFromStr("1647.25"), rates["ETH"])
+}
+
+func TestConvertTickersToUsdLiquidityFilter(t *testing.T) {
+	providerPrices := provider.AggregatedProviderPrices{}
+
+	providerPrices[provider.ProviderBinance] = map[string]types.TickerPrice{
+		"ATOMUSD": {
+			Price:  math.LegacyMustNewDecFromStr("10"),
+			Volume: math.LegacyMustNewDecFromStr("100"),
+		},
+		"USDCUSD": {
+			Price:  math.LegacyMustNewDecFromStr("1"),
+			Volume: math.LegacyMustNewDecFromStr("1000000"),
+		},
+	}
+
+	// deep pool
+	providerPrices[provider.ProviderOsmosisV2] = map[string]types.TickerPrice{
+		"ATOMUSDC": {
+			Price:     math.LegacyMustNewDecFromStr("10.2"),
+			Volume:    math.LegacyZeroDec(),
+			Liquidity: math.LegacyMustNewDecFromStr("3000"),
+		},
+	}
+
+	// thin pool
+	providerPrices[provider.ProviderAstroportNeutron] = map[string]types.TickerPrice{
+		"ATOMUSDC": {
+			Price:     math.LegacyMustNewDecFromStr("15"),
+			Volume:    math.LegacyMustNewDecFromStr("1000"),
+			Liquidity: math.LegacyMustNewDecFromStr("50"),
+		},
+	}
+
+	atomUsd := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+	atomUsdc := types.CurrencyPair{Base: "ATOM", Quote: "USDC"}
+	usdcUsd := types.CurrencyPair{Base: "USDC", Quote: "USD"}
+
+	providerPairs := map[provider.Name][]types.CurrencyPair{
+		provider.ProviderBinance:          {atomUsd, usdcUsd},
+		provider.ProviderOsmosisV2:        {atomUsdc},
+		provider.ProviderAstroportNeutron: {atomUsdc},
+	}
+
+	providerMinOverrides := map[string]int{
+		"ATOM": 1,
+		"USDC": 1,
+	}
+
+	liquidityFilters := map[string]LiquidityFilter{
+		"ATOM": {
+			MinTvl:   math.LegacyMustNewDecFromStr("1000"),
+			Weighted: true,
+		},
+	}
+
+	rates, err := convertTickersToUSD(
+		zerolog.Nop(),
+		providerPrices,
+		providerPairs,
+		make(map[string]math.LegacyDec),
+		providerMinOverrides,
+		nil,
+		liquidityFilters,
+	)
+	require.NoError(t, err)
+
+	// astroport is dropped, osmosis is weighted by its liquidity
+	// (10*1000 + 10.2*3000) / 4000 = 10.15
+	require.Equal(t, math.LegacyMustNewDecFromStr("10.15"), rates["ATOM"])
+}
@@ -0,0 +1,308 @@
+package formula
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"cosmossdk.io/math"
+)
+
+type (
+	// Expr defines a parsed formula that can be evaluated against a set of
+	// USD prices.
+	Expr interface {
+		Eval(prices map[string]math.LegacyDec) (math.LegacyDec, error)
+		Denoms() []string
+	}
+
+	number struct {
+		value math.LegacyDec
+	}
+
+	denom struct {
+		name string
+	}
+
+	negate struct {
+		expr Expr
+	}
+
+	binary struct {
+		op    byte
+		left  Expr
+		right Expr
+	}
+
+	parser struct {
+		input string
+		pos   int
+	}
+)
+
+// Parse parses a formula consisting of decimal numbers, denoms, the
+// operators + - * / and parentheses, e.g. "0.6*BTC + 0.4*ETH".
+func Parse(input string) (Expr, error) {
+	p := &parser{input: input}
+
+	expr, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos])
+	}
+
+	return expr, nil
+}
+
+func (p *parser) parseSum() (Expr, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.operator("+-")
+		if !ok {
+			return left, nil
+		}
+
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseProduct() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.operator("*/")
+		if !ok {
+			return left, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	_, ok := p.operator("-")
+	if ok {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negate{expr: expr}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return nil, p.errorf("unexpected end of formula")
+	}
+
+	c := rune(p.input[p.pos])
+
+	switch {
+	case c == '(':
+		p.pos++
+		expr, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+
+	case unicode.IsDigit(c) || c == '.':
+		start := p.pos
+		for p.pos < len(p.input) &&
+			(unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		value, err := math.LegacyNewDecFromStr(p.input[start:p.pos])
+		if err != nil {
+			return nil, p.errorf("invalid number %q", p.input[start:p.pos])
+		}
+		return number{value: value}, nil
+
+	case unicode.IsLetter(c):
+		start := p.pos
+		for p.pos < len(p.input) && isDenomChar(rune(p.input[p.pos])) {
+			p.pos++
+		}
+		return denom{name: strings.ToUpper(p.input[start:p.pos])}, nil
+	}
+
+	return nil, p.errorf("unexpected %q", c)
+}
+
+func (p *parser) operator(ops string) (byte, bool) {
+	p.skipSpaces()
+	if p.pos < len(p.input) && strings.IndexByte(ops, p.input[p.pos]) >= 0 {
+		op := p.input[p.pos]
+		p.pos++
+		return op, true
+	}
+	return 0, false
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(msg string, args ...interface{}) error {
+	return fmt.Errorf(
+		"formula %q at position %d: %s",
+		p.input, p.pos, fmt.Sprintf(msg, args...),
+	)
+}
+
+func isDenomChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_'
+}
+
+func (n number) Eval(map[string]math.LegacyDec) (math.LegacyDec, error) {
+	return n.value, nil
+}
+
+func (n number) Denoms() []string {
+	return nil
+}
+
+func (d denom) Eval(prices map[string]math.LegacyDec) (math.LegacyDec, error) {
+	price, found := prices[d.name]
+	if !found {
+		return math.LegacyDec{}, fmt.Errorf("missing price for %s", d.name)
+	}
+	return price, nil
+}
+
+func (d denom) Denoms() []string {
+	return []string{d.name}
+}
+
+func (n negate) Eval(prices map[string]math.LegacyDec) (math.LegacyDec, error) {
+	value, err := n.expr.Eval(prices)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+	return value.Neg(), nil
+}
+
+func (n negate) Denoms() []string {
+	return n.expr.Denoms()
+}
+
+func (b binary) Eval(prices map[string]math.LegacyDec) (math.LegacyDec, error) {
+	left, err := b.left.Eval(prices)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	right, err := b.right.Eval(prices)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	switch b.op {
+	case '+':
+		return left.Add(right), nil
+	case '-':
+		return left.Sub(right), nil
+	case '*':
+		return left.Mul(right), nil
+	case '/':
+		if right.IsZero() {
+			return math.LegacyDec{}, fmt.Errorf("division by zero")
+		}
+		return left.Quo(right), nil
+	}
+
+	return math.LegacyDec{}, fmt.Errorf("unknown operator %q", b.op)
+}
+
+func (b binary) Denoms() []string {
+	return append(b.left.Denoms(), b.right.Denoms()...)
+}
+
+// Order returns the names of the given formulas sorted so that every
+// formula comes after the formulas it depends on. Returns an error if the
+// formulas contain a cycle.
+func Order(formulas map[string]Expr) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := map[string]int{}
+	ordered := []string{}
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf(
+				"circular formula: %s", strings.Join(append(path, name), " -> "),
+			)
+		case done:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, dependency := range formulas[name].Denoms() {
+			_, found := formulas[dependency]
+			if !found {
+				continue
+			}
+			err := visit(dependency, append(path, name))
+			if err != nil {
+				return err
+			}
+		}
+		state[name] = done
+
+		ordered = append(ordered, name)
+		return nil
+	}
+
+	names := make([]string, 0, len(formulas))
+	for name := range formulas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		err := visit(name, []string{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
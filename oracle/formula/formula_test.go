@@ -0,0 +1,90 @@
+package formula
+
+import (
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	prices := map[string]math.LegacyDec{
+		"BTC":  math.LegacyMustNewDecFromStr("30000"),
+		"ETH":  math.LegacyMustNewDecFromStr("2000"),
+		"ATOM": math.LegacyMustNewDecFromStr("10"),
+	}
+
+	testCases := []struct {
+		formula  string
+		expected string
+	}{
+		{"0.6*BTC + 0.4*ETH", "18800"},
+		{"atom * 1.25", "12.5"},
+		{"(BTC - ETH) / 2", "14000"},
+		{"-ATOM + 2 * (ATOM + 1)", "12"},
+		{"BTC / ETH / 3", "5"},
+	}
+
+	for _, tc := range testCases {
+		expr, err := Parse(tc.formula)
+		require.NoError(t, err, tc.formula)
+
+		price, err := expr.Eval(prices)
+		require.NoError(t, err, tc.formula)
+		require.Equal(t, math.LegacyMustNewDecFromStr(tc.expected), price, tc.formula)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, formula := range []string{
+		"",
+		"BTC +",
+		"(BTC",
+		"BTC)",
+		"1.2.3",
+		"BTC $ ETH",
+	} {
+		_, err := Parse(formula)
+		require.Error(t, err, formula)
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	prices := map[string]math.LegacyDec{
+		"BTC":  math.LegacyMustNewDecFromStr("30000"),
+		"ZERO": math.LegacyZeroDec(),
+	}
+
+	expr, err := Parse("BTC / ZERO")
+	require.NoError(t, err)
+	_, err = expr.Eval(prices)
+	require.Error(t, err)
+
+	expr, err = Parse("BTC * ETH")
+	require.NoError(t, err)
+	_, err = expr.Eval(prices)
+	require.ErrorContains(t, err, "missing price for ETH")
+}
+
+func TestOrder(t *testing.T) {
+	parse := func(input string) Expr {
+		expr, err := Parse(input)
+		require.NoError(t, err)
+		return expr
+	}
+
+	ordered, err := Order(map[string]Expr{
+		"A": parse("B + C"),
+		"B": parse("C * 2"),
+		"C": parse("ATOM"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"C", "B", "A"}, ordered)
+
+	_, err = Order(map[string]Expr{
+		"A": parse("B + 1"),
+		"B": parse("C"),
+		"C": parse("A"),
+	})
+	require.ErrorContains(t, err, "circular formula")
+}
@@ -45,6 +45,13 @@ type ProviderWeight struct {
 	Weight map[string]math.LegacyDec
 }
 
+// LiquidityFilter defines the minimum USD liquidity a pool must hold for its
+// price to be considered and whether the liquidity is used as weight.
+type LiquidityFilter struct {
+	MinTvl   math.LegacyDec
+	Weighted bool
+}
+
 // PreviousPrevote defines a structure for defining the previous prevote
 // submitted on-chain.
 type PreviousPrevote struct {
@@ -65,8 +72,9 @@ func NewPreviousPrevote() *PreviousPrevote {
 // for a given set of currency pairs and determining the correct exchange rates
 // to submit to the on-chain price oracle adhering the oracle specification.
 type Oracle struct {
-	logger zerolog.Logger
-	closer *pfsync.Closer
+	logger    zerolog.Logger
+	closer    *pfsync.Closer
+	scheduler *provider.Scheduler
 
 	providerTimeout      time.Duration
 	providerPairs        map[provider.Name][]types.CurrencyPair
@@ -83,12 +91,25 @@ type Oracle struct {
 	derivativeSymbols    map[string]struct{}
 	contractAddresses    map[string]map[string]string
 	providerWeights      map[string]ProviderWeight
+	liquidityFilters     map[string]LiquidityFilter
+	pegs                 []Peg
+	synthetics           []Synthetic
+	precisions           map[string]Precision
 	decimals             map[string]map[string]int
 	periods              map[string]map[string]int
 	volumeDatabase       *sql.DB
 	bypassOracleParams   bool
 
+	// ticks and reloads are exclusive, the providers lock guards the
+	// providers and the settings read while initializing them
+	reloadMtx            sync.Mutex
+	tickMtx              sync.Mutex
+	providersMtx         sync.RWMutex
+	providerCancels      map[provider.Name]context.CancelFunc
+	unavailableProviders map[provider.Name]*ProviderFailure
+
 	mtx             sync.RWMutex
+	cancel          context.CancelFunc
 	lastPriceSyncTS time.Time
 	prices          map[string]math.LegacyDec
 	paramCache      ParamCache
@@ -110,20 +131,16 @@ func New(
 	history history.PriceHistory,
 	contractAddresses map[string]map[string]string,
 	providerWeights map[string]ProviderWeight,
+	liquidityFilters map[string]LiquidityFilter,
+	pegs []Peg,
+	synthetics []Synthetic,
+	precisions map[string]Precision,
 	decimals map[string]map[string]int,
 	periods map[string]map[string]int,
 	volumeDatabase *sql.DB,
 	bypassOracleParams bool,
 ) *Oracle {
-	providerPairs := make(map[provider.Name][]types.CurrencyPair)
-	for _, pair := range currencyPairs {
-		for _, provider := range pair.Providers {
-			providerPairs[provider] = append(providerPairs[provider], types.CurrencyPair{
-				Base:  pair.Base,
-				Quote: pair.Quote,
-			})
-		}
-	}
+	providerPairs := getProviderPairs(currencyPairs)
 	healthchecks := make(map[string]http.Client, len(healthchecksConfig))
 	for _, healthcheck := range healthchecksConfig {
 		timeout, err := time.ParseDuration(healthcheck.Timeout)
@@ -141,9 +158,12 @@ func New(
 	return &Oracle{
 		logger:               logger.With().Str("module", "oracle").Logger(),
 		closer:               pfsync.NewCloser(),
+		scheduler:            provider.NewScheduler(logger),
 		oracleClient:         oc,
 		providerPairs:        providerPairs,
 		priceProviders:       make(map[provider.Name]provider.Provider),
+		providerCancels:      make(map[provider.Name]context.CancelFunc),
+		unavailableProviders: make(map[provider.Name]*ProviderFailure),
 		previousPrevote:      nil,
 		providerTimeout:      providerTimeout,
 		deviations:           deviations,
@@ -157,6 +177,10 @@ func New(
 		history:              history,
 		contractAddresses:    contractAddresses,
 		providerWeights:      providerWeights,
+		liquidityFilters:     liquidityFilters,
+		pegs:                 pegs,
+		synthetics:           synthetics,
+		precisions:           precisions,
 		decimals:             decimals,
 		periods:              periods,
 		volumeDatabase:       volumeDatabase,
@@ -166,37 +190,94 @@ func New(
 
 // Start starts the oracle process in a blocking fashion.
 func (o *Oracle) Start(ctx context.Context) error {
+	// Stop cancels the context of the ticks and of the providers started
+	// by them
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	o.mtx.Lock()
+	o.cancel = cancel
+	o.mtx.Unlock()
+
 	for {
 		select {
 		case <-ctx.Done():
-			o.closer.Close()
+			o.Stop()
+			return nil
+
+		case <-o.closer.Done():
+			return nil
 
 		default:
 			o.logger.Debug().Msg("starting oracle tick")
 
 			startTime := time.Now()
 
+			o.tickMtx.Lock()
 			if err := o.tick(ctx); err != nil {
 				telemetry.IncrCounter(1, "failure", "tick")
 				o.logger.Err(err).Msg("oracle tick failed")
 			}
+			o.tickMtx.Unlock()
 
 			o.lastPriceSyncTS = time.Now()
 
 			telemetry.MeasureSince(startTime, "runtime", "tick")
 			telemetry.IncrCounter(1, "new", "tick")
 
-			time.Sleep(tickerSleep)
+			select {
+			case <-ctx.Done():
+			case <-o.closer.Done():
+			case <-time.After(tickerSleep):
+			}
 		}
 	}
 }
 
-// Stop stops the oracle process and waits for it to gracefully exit.
+// Stop stops the oracle process, cancels the running tick and the
+// websockets, health checks and retries of all providers, and waits for
+// their poll loops to gracefully exit.
 func (o *Oracle) Stop() {
 	o.closer.Close()
+
+	o.mtx.RLock()
+	if o.cancel != nil {
+		o.cancel()
+	}
+	o.mtx.RUnlock()
+
+	// wait for a running tick, no providers are started afterwards
+	o.tickMtx.Lock()
+	defer o.tickMtx.Unlock()
+
+	o.providersMtx.Lock()
+	for providerName, cancel := range o.providerCancels {
+		cancel()
+		delete(o.providerCancels, providerName)
+	}
+	o.providersMtx.Unlock()
+
+	o.scheduler.Stop()
 	<-o.closer.Done()
 }
 
+// GetProviderStatus returns the poll status of all providers.
+func (o *Oracle) GetProviderStatus() map[provider.Name]provider.PollStatus {
+	return o.scheduler.Status()
+}
+
+// PauseProvider stops polling a provider until it is resumed, returns
+// false for unknown providers.
+func (o *Oracle) PauseProvider(providerName provider.Name) bool {
+	return o.scheduler.Pause(providerName)
+}
+
+// ResumeProvider resumes polling a paused or quarantined provider, returns
+// false for unknown providers.
+func (o *Oracle) ResumeProvider(providerName provider.Name) bool {
+	return o.scheduler.Resume(providerName)
+}
+
 // GetLastPriceSyncTimestamp returns the latest timestamp at which prices where
 // fetched from the oracle's set of exchange rate providers.
 func (o *Oracle) GetLastPriceSyncTimestamp() time.Time {
@@ -236,33 +317,13 @@ func (o *Oracle) SetPrices(ctx context.Context) error {
 		providerName := providerName
 		currencyPairs := currencyPairs
 
+		o.providersMtx.RLock()
 		priceProvider, found := o.priceProviders[providerName]
-		if !found {
-			endpoint := o.endpoints[providerName]
-			contractAddresses := o.contractAddresses[providerName.String()]
-			decimals := o.decimals[providerName.String()]
-			periods := o.periods[providerName.String()]
-			endpoint.ContractAddresses = contractAddresses
-			endpoint.Decimals = decimals
-			endpoint.Periods = periods
-
-			newProvider, err := NewProvider(
-				o.volumeDatabase,
-				ctx,
-				providerName,
-				o.logger,
-				endpoint,
-				o.providerPairs[providerName]...,
-			)
-			if err != nil {
-				return err
-			}
-			priceProvider = newProvider
-
-			o.priceProviders[providerName] = priceProvider
-			continue
-		}
+		_, unavailable := o.unavailableProviders[providerName]
+		o.providersMtx.RUnlock()
 
+		// rates of unavailable and starting providers are required too, so
+		// that they are reported if no other provider has them
 		for _, pair := range currencyPairs {
 			_, ok := requiredRates[pair.Base]
 			if !ok {
@@ -270,6 +331,24 @@ func (o *Oracle) SetPrices(ctx context.Context) error {
 			}
 		}
 
+		// failed providers are retried in the background
+		if unavailable {
+			continue
+		}
+
+		if !found {
+			o.startProvider(ctx, providerName)
+			continue
+		}
+
+		// quarantined providers keep panicking, their prices are not used
+		if o.scheduler.Quarantined(providerName) {
+			o.logger.Warn().
+				Str("provider", providerName.String()).
+				Msg("skipping quarantined provider")
+			continue
+		}
+
 		g.Go(func() error {
 			prices := make(map[string]types.TickerPrice, 0)
 			ch := make(chan struct{})
@@ -372,11 +451,22 @@ func (o *Oracle) SetPrices(ctx context.Context) error {
 		o.deviations,
 		o.providerMinOverrides,
 		o.providerWeights,
+		o.liquidityFilters,
 	)
 	if err != nil {
 		return err
 	}
 
+	computedPrices = ApplyPegs(o.logger, computedPrices, o.pegs)
+	for _, peg := range o.pegs {
+		requiredRates[peg.Base] = struct{}{}
+	}
+
+	computedPrices = ApplySynthetics(o.logger, computedPrices, o.synthetics)
+	for _, synthetic := range o.synthetics {
+		requiredRates[synthetic.Denom] = struct{}{}
+	}
+
 	if len(computedPrices) != len(requiredRates) {
 		missingPrices := []string{}
 		for base := range requiredRates {
@@ -391,7 +481,7 @@ func (o *Oracle) SetPrices(ctx context.Context) error {
 		)
 	}
 
-	o.prices = computedPrices
+	o.prices = RoundPrices(computedPrices, o.precisions)
 
 	return nil
 }
@@ -407,6 +497,7 @@ func GetComputedPrices(
 	deviations map[string]math.LegacyDec,
 	providerMinOverrides map[string]int,
 	providerWeights map[string]ProviderWeight,
+	liquidityFilters map[string]LiquidityFilter,
 ) (prices map[string]math.LegacyDec, err error) {
 	rates, err := convertTickersToUSD(
 		logger,
@@ -415,6 +506,7 @@ func GetComputedPrices(
 		deviations,
 		providerMinOverrides,
 		providerWeights,
+		liquidityFilters,
 	)
 	if err != nil {
 		return nil, err
@@ -491,110 +583,23 @@ func NewProvider(
 ) (provider.Provider, error) {
 	endpoint.Name = providerName
 	providerLogger := logger.With().Str("provider", providerName.String()).Logger()
-	switch providerName {
-
-	case
-		provider.ProviderAstroportNeutron,
-		provider.ProviderAstroportTerra2,
-		provider.ProviderAstroportInjective:
-		return provider.NewAstroportProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case
-		provider.ProviderBinance,
-		provider.ProviderBinanceUS:
-		return provider.NewBinanceProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderBingx:
-		return provider.NewBingxProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderBitfinex:
-		return provider.NewBitfinexProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderBitget:
-		return provider.NewBitgetProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderBitstamp:
-		return provider.NewBitstampProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderBkex:
-		return provider.NewBkexProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderBitmart:
-		return provider.NewBitmartProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderBybit:
-		return provider.NewBybitProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderCamelotV2, provider.ProviderCamelotV3:
-		return provider.NewCamelotProvider(db, ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderCoinbase:
-		return provider.NewCoinbaseProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderCoinex:
-		return provider.NewCoinexProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderCrypto:
-		return provider.NewCryptoProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderCurve:
-		return provider.NewCurveProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderDexter:
-		return provider.NewDexterProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderFin:
-		return provider.NewFinProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderFinV2:
-		return provider.NewFinV2Provider(db, ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderGate:
-		return provider.NewGateProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderHelix:
-		return provider.NewHelixProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderHitBtc:
-		return provider.NewHitBtcProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderHuobi:
-		return provider.NewHuobiProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderIdxOsmosis:
-		return provider.NewIdxProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderKraken:
-		return provider.NewKrakenProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderKucoin:
-		return provider.NewKucoinProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderLbank:
-		return provider.NewLbankProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderMaya:
-		return provider.NewMayaProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderMexc:
-		return provider.NewMexcProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderMock:
-		return provider.NewMockProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderOkx:
-		return provider.NewOkxProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderOsmosis:
-		return provider.NewOsmosisProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderOsmosisV2:
-		return provider.NewOsmosisV2Provider(db, ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderPancakeV3Bsc:
-		return provider.NewPancakeProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderPhemex:
-		return provider.NewPhemexProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderPionex:
-		return provider.NewPionexProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderPoloniex:
-		return provider.NewPoloniexProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderPyth:
-		return provider.NewPythProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderShade:
-		return provider.NewShadeProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderUniswapV3:
-		return provider.NewUniswapV3Provider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderUnstake:
-		return provider.NewUnstakeProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderVelodromeV2:
-		return provider.NewVelodromeV2Provider(ctx, providerLogger, endpoint, providerPairs...)
-	case
-		provider.ProviderWhitewhaleCmdx,
-		provider.ProviderWhitewhaleHuahua,
-		provider.ProviderWhitewhaleInj,
-		provider.ProviderWhitewhaleJuno,
-		provider.ProviderWhitewhaleLunc,
-		provider.ProviderWhitewhaleLuna,
-		provider.ProviderWhitewhaleSei,
-		provider.ProviderWhitewhaleWhale:
-		return provider.NewWhitewhaleProvider(db, ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderXt:
-		return provider.NewXtProvider(ctx, providerLogger, endpoint, providerPairs...)
-	case provider.ProviderZero:
-		return provider.NewZeroProvider(ctx, providerLogger, endpoint, providerPairs...)
-
-	}
-	return nil, fmt.Errorf("provider %s not found", providerName)
+
+	registration, found := provider.Lookup(endpoint.TypeName())
+	if !found {
+		return nil, fmt.Errorf("provider %s not found", providerName)
+	}
+
+	if registration.NeedsDecimals {
+		for _, pair := range providerPairs {
+			for _, denom := range []string{pair.Base, pair.Quote} {
+				if _, found := endpoint.Decimals[denom]; !found {
+					providerLogger.Warn().Str("denom", denom).Msg("decimals not configured")
+				}
+			}
+		}
+	}
+
+	return registration.New(db, ctx, providerLogger, endpoint, providerPairs...)
 }
 
 func (o *Oracle) checkWhitelist(params oracletypes.Params) {
@@ -609,8 +614,12 @@ func (o *Oracle) checkWhitelist(params oracletypes.Params) {
 func (o *Oracle) tick(ctx context.Context) error {
 	o.logger.Info().Msg("executing oracle tick")
 
+	o.providersMtx.RLock()
+	initialized := len(o.priceProviders)
+	o.providersMtx.RUnlock()
+
 	// Create and start all provider routines immediately
-	if len(o.priceProviders) == 0 {
+	if initialized == 0 {
 		o.logger.Debug().Msg("no price providers found, initializing SetPrices")
 		o.SetPrices(ctx)
 	}
@@ -863,7 +872,7 @@ func GenerateSalt(length int) (string, error) {
 }
 
 // GenerateExchangeRatesString generates a canonical string representation of
-// the aggregated exchange rates.
+// the aggregated exchange rates. Prices are printed without trailing zeros.
 func GenerateExchangeRatesString(prices sdk.DecCoins) string {
 	prices.Sort()
 
@@ -873,7 +882,7 @@ func GenerateExchangeRatesString(prices sdk.DecCoins) string {
 
 	var pairs []string
 	for _, price := range prices {
-		pairs = append(pairs, fmt.Sprintf("%s:%s", price.Denom, price.Amount.String()))
+		pairs = append(pairs, fmt.Sprintf("%s:%s", price.Denom, FormatPrice(price.Amount)))
 	}
 
 	return strings.Join(pairs, ",")
@@ -1,11 +1,15 @@
 package oracle
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
@@ -111,6 +115,11 @@ func (ots *OracleTestSuite) SetupSuite() {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		false,
 	)
 }
 
@@ -218,7 +227,10 @@ func (ots *OracleTestSuite) TestPrices() {
 
 	prices = ots.oracle.GetPrices()
 	ots.Require().Len(prices, 4)
-	ots.Require().Equal(math.LegacyNewDecFromStr("3.710916056220858266"), prices.AmountOf("UMEE"))
+	// the UMEE tickers are weighted by their USD notional volume:
+	// (3.72 * 3.72*2396974.02 + 3.70 * 3.70*1994674.34) /
+	// (3.72*2396974.02 + 3.70*1994674.34)
+	ots.Require().Equal(math.LegacyNewDecFromStr("3.710942777612422485"), prices.AmountOf("UMEE"))
 	ots.Require().Equal(math.LegacyNewDecFromStr("3.717"), prices.AmountOf("XBT"))
 	ots.Require().Equal(math.LegacyNewDecFromStr("1"), prices.AmountOf("USDC"))
 	ots.Require().Equal(math.LegacyNewDecFromStr("1"), prices.AmountOf("USDT"))
@@ -342,23 +354,29 @@ func TestGenerateSalt(t *testing.T) {
 
 func TestGenerateExchangeRatesString(t *testing.T) {
 	testCases := map[string]struct {
-		input    math.LegacyDecCoins
+		input    sdk.DecCoins
 		expected string
 	}{
 		"empty input": {
 			input:    sdk.NewDecCoins(),
-			expected: "",
+			expected: "EXCHANGE_RATE=",
 		},
 		"single denom": {
-			input:    sdk.NewDecCoins(sdk.NewDecCoinFromDec("UMEE", math.LegacyNewDecFromStr("3.72"))),
-			expected: "3.720000000000000000UMEE",
+			input:    sdk.NewDecCoins(sdk.NewDecCoinFromDec("UMEE", math.LegacyMustNewDecFromStr("3.72"))),
+			expected: "UMEE:3.72",
 		},
 		"multi denom": {
-			input: sdk.NewDecCoins(sdk.NewDecCoinFromDec("UMEE", math.LegacyNewDecFromStr("3.72")),
-				sdk.NewDecCoinFromDec("ATOM", math.LegacyNewDecFromStr("40.13")),
-				sdk.NewDecCoinFromDec("OSMO", math.LegacyNewDecFromStr("8.69")),
+			input: sdk.NewDecCoins(sdk.NewDecCoinFromDec("UMEE", math.LegacyMustNewDecFromStr("3.72")),
+				sdk.NewDecCoinFromDec("ATOM", math.LegacyMustNewDecFromStr("40.13")),
+				sdk.NewDecCoinFromDec("OSMO", math.LegacyMustNewDecFromStr("8.69")),
 			),
-			expected: "40.130000000000000000ATOM,8.690000000000000000OSMO,3.720000000000000000UMEE",
+			expected: "ATOM:40.13,OSMO:8.69,UMEE:3.72",
+		},
+		"integer and full precision": {
+			input: sdk.NewDecCoins(sdk.NewDecCoinFromDec("BTC", math.LegacyMustNewDecFromStr("30000")),
+				sdk.NewDecCoinFromDec("SHIB", math.LegacyMustNewDecFromStr("0.000008123456789012")),
+			),
+			expected: "BTC:30000,SHIB:0.000008123456789012",
 		},
 	}
 
@@ -404,6 +422,7 @@ func TestSuccessGetComputedPricesTickers(t *testing.T) {
 		make(map[string]math.LegacyDec),
 		providerMinOverrides,
 		nil,
+		nil,
 	)
 
 	require.NoError(t, err, "It should successfully get computed ticker prices")
@@ -487,15 +506,186 @@ func TestGetComputedPricesTickersConversion(t *testing.T) {
 		make(map[string]math.LegacyDec),
 		providerMinOverrides,
 		nil,
+		nil,
 	)
 
 	require.NoError(t, err,
 		"It should successfully filter out bad tickers and convert everything to USD",
 	)
+	// both tickers share the same base volume, weighted by USD notional
+	// the result is (a*a + b*b) / (a + b)
+	btcEthUsdPrice := ethUsdPrice.Mul(btcEthPrice)
 	require.Equal(t,
-
-		ethUsdPrice.Mul(
-			btcEthPrice).Add(btcUsdPrice).Quo(math.LegacyNewDecFromStr("2")),
+		btcEthUsdPrice.Mul(btcEthUsdPrice).Add(btcUsdPrice.Mul(btcUsdPrice)).Quo(
+			btcEthUsdPrice.Add(btcUsdPrice),
+		),
 		prices[btcEthPair.Base],
 	)
 }
+
+func TestSetPrices_UnavailableProvider(t *testing.T) {
+	history, err := history.NewPriceHistory(":memory:", zerolog.Nop())
+	require.NoError(t, err)
+
+	logs := &syncBuffer{}
+	oracle := New(
+		zerolog.New(logs),
+		client.OracleClient{},
+		[]config.CurrencyPair{
+			{
+				Base:      "UMEE",
+				Quote:     "USDT",
+				Providers: []provider.Name{"unknown", provider.ProviderBinance},
+			},
+			{
+				Base:      "OSMO",
+				Quote:     "USDT",
+				Providers: []provider.Name{"unknown"},
+			},
+		},
+		time.Millisecond*100,
+		make(map[string]math.LegacyDec),
+		make(map[string]int),
+		make(map[provider.Name]provider.Endpoint),
+		map[string]derivative.Derivative{},
+		map[string][]types.CurrencyPair{},
+		map[string]struct{}{},
+		nil,
+		history,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		false,
+	)
+	defer oracle.Stop()
+
+	oracle.priceProviders[provider.ProviderBinance] = mockProvider{}
+
+	// the failed provider doesn't block the others and is not initialized
+	// again while it is retried
+	require.NoError(t, oracle.SetPrices(context.Background()))
+	require.NoError(t, oracle.SetPrices(context.Background()))
+	require.Contains(t, oracle.priceProviders, provider.ProviderBinance)
+
+	unavailable := oracle.GetUnavailableProviders()
+	require.Len(t, unavailable, 1)
+	require.Equal(t, 1, unavailable["unknown"].Attempts)
+	require.Contains(t, unavailable["unknown"].Error, "not found")
+
+	// the pairs of the unavailable provider are still reported as missing
+	require.Contains(t, logs.String(), "unable to get prices for: OSMO")
+}
+
+// syncBuffer collects the logs of the oracle and its goroutines.
+type syncBuffer struct {
+	mtx sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.buf.String()
+}
+
+// failingAttempts receives the contexts of the failing test provider.
+var failingAttempts = make(chan context.Context, 1)
+
+func init() {
+	provider.Register(provider.Registration{
+		Name: "failing",
+		New: func(
+			_ *sql.DB,
+			ctx context.Context,
+			_ zerolog.Logger,
+			_ provider.Endpoint,
+			_ ...types.CurrencyPair,
+		) (provider.Provider, error) {
+			failingAttempts <- ctx
+			return nil, fmt.Errorf("failed after starting the websocket")
+		},
+	})
+}
+
+func TestInitProvider_CancelsFailedAttempt(t *testing.T) {
+	oracle := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		[]config.CurrencyPair{},
+		time.Millisecond*100,
+		make(map[string]math.LegacyDec),
+		make(map[string]int),
+		make(map[provider.Name]provider.Endpoint),
+		map[string]derivative.Derivative{},
+		map[string][]types.CurrencyPair{},
+		map[string]struct{}{},
+		nil,
+		history.PriceHistory{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		false,
+	)
+	defer oracle.Stop()
+
+	// goroutines started by the failed constructor are stopped with it
+	_, err := oracle.initProvider(context.Background(), "failing")
+	require.Error(t, err)
+	require.ErrorIs(t, (<-failingAttempts).Err(), context.Canceled)
+}
+
+func TestStop(t *testing.T) {
+	oracle := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		[]config.CurrencyPair{},
+		time.Millisecond*100,
+		make(map[string]math.LegacyDec),
+		make(map[string]int),
+		make(map[provider.Name]provider.Endpoint),
+		map[string]derivative.Derivative{},
+		map[string][]types.CurrencyPair{},
+		map[string]struct{}{},
+		nil,
+		history.PriceHistory{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		false,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	oracle.providerCancels[provider.ProviderBinance] = cancel
+
+	// the websockets, health checks and retries of the providers are
+	// stopped with their context
+	oracle.Stop()
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	require.Empty(t, oracle.providerCancels)
+}
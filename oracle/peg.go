@@ -0,0 +1,139 @@
+package oracle
+
+import (
+	"price-feeder/config"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/hashicorp/go-metrics"
+	"github.com/rs/zerolog"
+)
+
+// Peg defines an asset whose price is derived from a fixed rate. The price
+// is Rate × price of Quote, or the constant Rate if Quote is USD. If Band is
+// set, market prices of the asset that depart further than Band (relative)
+// from the peg are reported.
+type Peg struct {
+	Base  string
+	Quote string
+	Rate  math.LegacyDec
+	Band  math.LegacyDec
+}
+
+// ApplyPegs sets the prices of all pegged assets. Market prices that were
+// computed for a pegged asset are only used for the sanity band check and
+// are replaced by the peg. Pegs can depend on other pegs, they are resolved
+// until no further peg can be computed.
+func ApplyPegs(
+	logger zerolog.Logger,
+	prices map[string]math.LegacyDec,
+	pegs []Peg,
+) map[string]math.LegacyDec {
+	pegged := make(map[string]math.LegacyDec, len(pegs))
+	unresolved := pegs
+
+	for len(unresolved) > 0 {
+		pending := []Peg{}
+
+		for _, peg := range unresolved {
+			rate, ok := pegQuoteRate(prices, pegged, peg)
+			if !ok {
+				pending = append(pending, peg)
+				continue
+			}
+
+			pegged[peg.Base] = peg.Rate.Mul(rate)
+		}
+
+		if len(pending) == len(unresolved) {
+			for _, peg := range pending {
+				logger.Error().
+					Str("denom", peg.Base).
+					Str("quote", peg.Quote).
+					Msg("unable to get quote price for peg")
+			}
+			break
+		}
+
+		unresolved = pending
+	}
+
+	for denom, price := range pegged {
+		market, found := prices[denom]
+		if found {
+			checkPegBand(logger, denom, market, price, pegs)
+		}
+
+		prices[denom] = price
+	}
+
+	return prices
+}
+
+// pegQuoteRate returns the USD price of the quote of the peg, preferring
+// pegged prices over market prices.
+func pegQuoteRate(
+	prices map[string]math.LegacyDec,
+	pegged map[string]math.LegacyDec,
+	peg Peg,
+) (math.LegacyDec, bool) {
+	if peg.Quote == config.DenomUSD {
+		return math.LegacyOneDec(), true
+	}
+
+	rate, found := pegged[peg.Quote]
+	if found {
+		return rate, true
+	}
+
+	rate, found = prices[peg.Quote]
+	return rate, found
+}
+
+func checkPegBand(
+	logger zerolog.Logger,
+	denom string,
+	market math.LegacyDec,
+	price math.LegacyDec,
+	pegs []Peg,
+) {
+	var band math.LegacyDec
+	for _, peg := range pegs {
+		if peg.Base == denom {
+			band = peg.Band
+		}
+	}
+
+	if price.IsZero() {
+		return
+	}
+
+	deviation := market.Quo(price).Sub(math.LegacyOneDec()).Abs()
+
+	labels := []metrics.Label{
+		telemetry.NewLabel("denom", denom),
+	}
+
+	telemetry.SetGaugeWithLabels(
+		[]string{"peg", "deviation"},
+		float32(deviation.MustFloat64()),
+		labels,
+	)
+
+	if band.IsNil() || deviation.LTE(band) {
+		return
+	}
+
+	telemetry.IncrCounterWithLabels(
+		[]string{"failure", "peg"},
+		1,
+		labels,
+	)
+
+	logger.Warn().
+		Str("denom", denom).
+		Str("market", market.String()).
+		Str("peg", price.String()).
+		Str("deviation", deviation.String()).
+		Msg("market price departs from peg")
+}
@@ -0,0 +1,52 @@
+package oracle
+
+import (
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPegs(t *testing.T) {
+	prices := map[string]math.LegacyDec{
+		"ETH":     math.LegacyMustNewDecFromStr("2000"),
+		"AXLUSDC": math.LegacyMustNewDecFromStr("0.9"),
+	}
+
+	pegs := []Peg{
+		// depends on the pegged USDC price
+		{
+			Base:  "AXLUSDC",
+			Quote: "USDC",
+			Rate:  math.LegacyOneDec(),
+			Band:  math.LegacyMustNewDecFromStr("0.02"),
+		},
+		{
+			Base:  "USDC",
+			Quote: "USD",
+			Rate:  math.LegacyOneDec(),
+		},
+		{
+			Base:  "WSTETH",
+			Quote: "ETH",
+			Rate:  math.LegacyMustNewDecFromStr("1.15"),
+		},
+		{
+			Base:  "FOO",
+			Quote: "BAR",
+			Rate:  math.LegacyOneDec(),
+		},
+	}
+
+	prices = ApplyPegs(zerolog.Nop(), prices, pegs)
+
+	require.Equal(t, math.LegacyOneDec(), prices["USDC"])
+	// market price outside of the band is replaced by the peg
+	require.Equal(t, math.LegacyOneDec(), prices["AXLUSDC"])
+	require.Equal(t, math.LegacyMustNewDecFromStr("2300"), prices["WSTETH"])
+	require.Equal(t, math.LegacyMustNewDecFromStr("2000"), prices["ETH"])
+
+	_, found := prices["FOO"]
+	require.False(t, found)
+}
@@ -0,0 +1,126 @@
+package oracle
+
+import (
+	"fmt"
+	"strings"
+
+	"cosmossdk.io/math"
+)
+
+// RoundingMode defines how prices are rounded to their configured precision.
+type RoundingMode uint8
+
+const (
+	RoundHalfEven RoundingMode = iota
+	RoundFloor
+	RoundCeil
+)
+
+// Precision defines the output precision of a denom, either as significant
+// figures or, if SignificantFigures is zero, as decimal places.
+type Precision struct {
+	SignificantFigures int64
+	DecimalPlaces      int64
+	Mode               RoundingMode
+}
+
+// NewRoundingMode parses the name of a rounding mode. An empty name
+// defaults to half-even.
+func NewRoundingMode(name string) (RoundingMode, error) {
+	switch strings.ToLower(name) {
+	case "", "half_even":
+		return RoundHalfEven, nil
+	case "floor":
+		return RoundFloor, nil
+	case "ceil":
+		return RoundCeil, nil
+	}
+	return 0, fmt.Errorf("unsupported rounding mode: %s", name)
+}
+
+// Round rounds the price to the configured precision.
+func (p Precision) Round(price math.LegacyDec) math.LegacyDec {
+	if price.IsZero() {
+		return price
+	}
+
+	places := p.DecimalPlaces
+	if p.SignificantFigures > 0 {
+		places = p.SignificantFigures - magnitude(price)
+	}
+
+	if places >= math.LegacyPrecision {
+		return price
+	}
+
+	var scaled math.LegacyDec
+	if places >= 0 {
+		scaled = price.Mul(pow10(places))
+	} else {
+		scaled = price.Quo(pow10(-places))
+	}
+
+	switch p.Mode {
+	case RoundFloor:
+		truncated := scaled.TruncateDec()
+		if scaled.IsNegative() && !truncated.Equal(scaled) {
+			truncated = truncated.Sub(math.LegacyOneDec())
+		}
+		scaled = truncated
+	case RoundCeil:
+		scaled = scaled.Ceil()
+	default:
+		scaled = math.LegacyNewDecFromInt(scaled.RoundInt())
+	}
+
+	if places >= 0 {
+		return scaled.Quo(pow10(places))
+	}
+	return scaled.Mul(pow10(-places))
+}
+
+// RoundPrices rounds all prices with a configured precision.
+func RoundPrices(
+	prices map[string]math.LegacyDec,
+	precisions map[string]Precision,
+) map[string]math.LegacyDec {
+	for denom, price := range prices {
+		precision, found := precisions[denom]
+		if found {
+			prices[denom] = precision.Round(price)
+		}
+	}
+	return prices
+}
+
+// FormatPrice returns the canonical representation of a price without
+// trailing zeros.
+func FormatPrice(price math.LegacyDec) string {
+	str := price.String()
+	if strings.Contains(str, ".") {
+		str = strings.TrimRight(str, "0")
+		str = strings.TrimSuffix(str, ".")
+	}
+	return str
+}
+
+// magnitude returns the position of the most significant digit of the
+// price, e.g. 3 for 123.4 and -1 for 0.0123.
+func magnitude(price math.LegacyDec) int64 {
+	abs := price.Abs()
+	if abs.GTE(math.LegacyOneDec()) {
+		return int64(len(abs.TruncateInt().String()))
+	}
+
+	var exponent int64
+	ten := math.LegacyNewDec(10)
+	for abs.LT(math.LegacyOneDec()) {
+		abs = abs.Mul(ten)
+		exponent--
+	}
+	return exponent + 1
+}
+
+func pow10(exponent int64) math.LegacyDec {
+	return math.LegacyNewDec(10).Power(uint64(exponent))
+}
@@ -0,0 +1,72 @@
+package oracle
+
+import (
+	"testing"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrecisionRound(t *testing.T) {
+	sigFigs := func(n int64, mode RoundingMode) Precision {
+		return Precision{SignificantFigures: n, Mode: mode}
+	}
+	decimals := func(n int64, mode RoundingMode) Precision {
+		return Precision{DecimalPlaces: n, Mode: mode}
+	}
+
+	testCases := []struct {
+		precision Precision
+		price     string
+		expected  string
+	}{
+		{sigFigs(3, RoundHalfEven), "123.45", "123"},
+		{sigFigs(3, RoundHalfEven), "0.012345", "0.0123"},
+		{sigFigs(2, RoundHalfEven), "125", "120"},
+		{sigFigs(2, RoundHalfEven), "135", "140"},
+		{sigFigs(3, RoundFloor), "29999.9", "29900"},
+		{sigFigs(3, RoundCeil), "29900.1", "30000"},
+		{sigFigs(18, RoundHalfEven), "0.000000000000000001", "0.000000000000000001"},
+		{decimals(2, RoundHalfEven), "1.005", "1"},
+		{decimals(2, RoundHalfEven), "1.015", "1.02"},
+		{decimals(0, RoundFloor), "7.9", "7"},
+		{decimals(4, RoundCeil), "0.00001", "0.0001"},
+		{decimals(18, RoundFloor), "3.141592653589793238", "3.141592653589793238"},
+		{decimals(2, RoundHalfEven), "0", "0"},
+	}
+
+	for _, tc := range testCases {
+		price := math.LegacyMustNewDecFromStr(tc.price)
+		rounded := tc.precision.Round(price)
+		require.Equal(t, tc.expected, FormatPrice(rounded), tc.price)
+	}
+}
+
+func TestRoundedExchangeRatesString(t *testing.T) {
+	prices := map[string]math.LegacyDec{
+		"BTC":  math.LegacyMustNewDecFromStr("30017.501179867299630771"),
+		"ETH":  math.LegacyMustNewDecFromStr("1647.251234567890123456"),
+		"USDT": math.LegacyMustNewDecFromStr("0.999876543210987654"),
+		"ATOM": math.LegacyMustNewDecFromStr("10.123456789012345678"),
+	}
+
+	precisions := map[string]Precision{
+		"BTC":  {SignificantFigures: 6, Mode: RoundHalfEven},
+		"ETH":  {SignificantFigures: 6, Mode: RoundHalfEven},
+		"USDT": {DecimalPlaces: 4, Mode: RoundFloor},
+	}
+
+	prices = RoundPrices(prices, precisions)
+
+	coins := sdk.NewDecCoins()
+	for denom, price := range prices {
+		coins = coins.Add(sdk.NewDecCoinFromDec(denom, price))
+	}
+
+	require.Equal(
+		t,
+		"ATOM:10.123456789012345678,BTC:30017.5,ETH:1647.25,USDT:0.9998",
+		GenerateExchangeRatesString(coins),
+	)
+}
@@ -85,8 +85,44 @@ type (
 		Spread     string `json:"spread_amount"`
 		Commission string `json:"commission_amount"`
 	}
+
+	AstroportPoolResponse struct {
+		Data AstroportPoolData `json:"data"`
+	}
+
+	AstroportPoolData struct {
+		Assets []AstroportPoolAsset `json:"assets"`
+	}
+
+	AstroportPoolAsset struct {
+		Info   AstroportAsset `json:"info"`
+		Amount string         `json:"amount"`
+	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:          ProviderAstroportInjective,
+			Defaults:      astroportInjectiveDefaultEndpoints,
+			New:           withoutDb(NewAstroportProvider),
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderAstroportNeutron,
+			Defaults:      astroportNeutronDefaultEndpoints,
+			New:           withoutDb(NewAstroportProvider),
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderAstroportTerra2,
+			Defaults:      astroportTerra2DefaultEndpoints,
+			New:           withoutDb(NewAstroportProvider),
+			NeedsDecimals: true,
+		},
+	)
+}
+
 func NewAstroportProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -110,7 +146,7 @@ func NewAstroportProvider(
 
 	provider.denoms = provider.getDenoms()
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -182,6 +218,7 @@ func (p *AstroportProvider) Poll() error {
 		_, found = p.pairs[pair.String()]
 		if !found {
 			price = floatToDec(1).Quo(price)
+			offerAsset, askAsset = askAsset, offerAsset
 		}
 
 		p.setTickerPrice(
@@ -190,11 +227,75 @@ func (p *AstroportProvider) Poll() error {
 			math.LegacyZeroDec(),
 			timestamp,
 		)
+
+		liquidity, err := p.getLiquidity(contract, symbol, offerAsset, askAsset, price)
+		if err != nil {
+			p.logger.Warn().
+				Err(err).
+				Str("symbol", symbol).
+				Msg("failed to get pool liquidity")
+			continue
+		}
+
+		p.setTickerLiquidity(symbol, liquidity)
 	}
 
 	return nil
 }
 
+// getLiquidity returns the pool reserves valued in the quote asset of the
+// provider symbol. Requires the decimals of both assets to be configured.
+func (p *AstroportProvider) getLiquidity(
+	contract string,
+	symbol string,
+	base AstroportAsset,
+	quote AstroportAsset,
+	price math.LegacyDec,
+) (math.LegacyDec, error) {
+	pair, found := p.getPair(symbol)
+	if !found {
+		return math.LegacyDec{}, fmt.Errorf("pair not found")
+	}
+
+	decimalsBase, found := p.endpoints.Decimals[pair.Base]
+	if !found {
+		return math.LegacyDec{}, fmt.Errorf("no decimals found")
+	}
+
+	decimalsQuote, found := p.endpoints.Decimals[pair.Quote]
+	if !found {
+		return math.LegacyDec{}, fmt.Errorf("no decimals found")
+	}
+
+	content, err := p.wasmSmartQuery(contract, `{"pool":{}}`)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	var response AstroportPoolResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return math.LegacyDec{}, p.error(err)
+	}
+
+	var reserveBase, reserveQuote math.LegacyDec
+	for _, asset := range response.Data.Assets {
+		amount := strToDec(asset.Amount)
+		switch asset.Info.String() {
+		case base.String():
+			reserveBase = amount.Quo(uintToDec(10).Power(uint64(decimalsBase)))
+		case quote.String():
+			reserveQuote = amount.Quo(uintToDec(10).Power(uint64(decimalsQuote)))
+		}
+	}
+
+	if reserveBase.IsNil() || reserveQuote.IsNil() {
+		return math.LegacyDec{}, p.errorf("pool reserves not found")
+	}
+
+	return poolLiquidity(reserveBase, reserveQuote, price), nil
+}
+
 func (p *AstroportProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	return p.getAvailablePairsFromContracts()
 }
@@ -245,3 +346,14 @@ func (p *AstroportProvider) getDenoms() map[string]AstroportAsset {
 
 	return assets
 }
+
+// String returns the native denom or the token contract of the asset.
+func (a AstroportAsset) String() string {
+	if a.NativeToken != nil {
+		return a.NativeToken.Denom
+	}
+	if a.Token != nil {
+		return a.Token.ContractAddress
+	}
+	return ""
+}
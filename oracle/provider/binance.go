@@ -3,7 +3,10 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
 	"price-feeder/oracle/types"
@@ -14,14 +17,20 @@ import (
 var (
 	_                       Provider = (*BinanceProvider)(nil)
 	binanceDefaultEndpoints          = Endpoint{
-		Name:         ProviderBinance,
-		Urls:         []string{"https://api.binance.com"},
-		PollInterval: 6 * time.Second,
+		Name:          ProviderBinance,
+		Urls:          []string{"https://api.binance.com"},
+		PollInterval:  6 * time.Second,
+		Websocket:     "stream.binance.com:9443",
+		WebsocketPath: "/ws",
+		RateLimit:     binanceRateLimit(6000),
 	}
 	binanceUSDefaultEndpoints = Endpoint{
-		Name:         ProviderBinanceUS,
-		Urls:         []string{"https://api.binance.us"},
-		PollInterval: 6 * time.Second,
+		Name:          ProviderBinanceUS,
+		Urls:          []string{"https://api.binance.us"},
+		PollInterval:  6 * time.Second,
+		Websocket:     "stream.binance.us:9443",
+		WebsocketPath: "/ws",
+		RateLimit:     binanceRateLimit(1200),
 	}
 )
 
@@ -39,9 +48,84 @@ type (
 		Symbol    string `json:"symbol"`    // Symbol ex.: BTCUSDT
 		LastPrice string `json:"lastPrice"` // Last price ex.: 0.0025
 		Volume    string `json:"volume"`    // Total traded base asset volume ex.: 20
+		CloseTime int64  `json:"closeTime"` // Statistics close time ex.: 1499869899040
+	}
+
+	// BinanceWsTicker requires the event time field, json keys are case
+	// insensitive and "E" would otherwise be decoded into the event type.
+	BinanceWsTicker struct {
+		Event     string `json:"e"` // Event type ex.: 24hrMiniTicker
+		EventTime int64  `json:"E"` // Event time ex.: 1672515782136
+		Symbol    string `json:"s"` // Symbol ex.: BTCUSDT
+		LastPrice string `json:"c"` // Last price ex.: 0.0025
+		Volume    string `json:"v"` // Total traded base asset volume ex.: 20
+	}
+
+	BinanceTrade struct {
+		ID    int64  `json:"id"`    // ex.: 28457
+		Price string `json:"price"` // ex.: "4.00000100"
+		Qty   string `json:"qty"`   // ex.: "12.00000000"
+		Time  int64  `json:"time"`  // ex.: 1499865549590
+	}
+
+	BinanceWsTrade struct {
+		Event     string `json:"e"` // Event type ex.: trade
+		EventTime int64  `json:"E"` // Event time ex.: 1672515782136
+		Symbol    string `json:"s"` // Symbol ex.: BTCUSDT
+		ID        int64  `json:"t"` // Trade ID ex.: 12345
+		Price     string `json:"p"` // Price ex.: "0.001"
+		Qty       string `json:"q"` // Quantity ex.: "100"
+		Time      int64  `json:"T"` // Trade time ex.: 1672515782136
+	}
+
+	BinanceOrderBook struct {
+		Bids [][]json.RawMessage `json:"bids"` // ex.: [["4.00000000", "431.00000000"]]
+		Asks [][]json.RawMessage `json:"asks"` // ex.: [["4.00000200", "12.00000000"]]
+	}
+
+	BinanceSubscriptionMsg struct {
+		Method string   `json:"method"` // SUBSCRIBE
+		Params []string `json:"params"` // streams ex.: btcusdt@miniTicker
+		ID     uint64   `json:"id"`
 	}
 )
 
+// binanceRateLimit returns the request weights of the endpoints in use and
+// the weight limit per minute, reported in the X-MBX-USED-WEIGHT-1M header.
+//
+// REF: https://binance-docs.github.io/apidocs/spot/en/#limits
+func binanceRateLimit(maxWeight int) *RateLimitOptions {
+	return &RateLimitOptions{
+		Requests: maxWeight,
+		Interval: time.Minute,
+		Weights: map[string]int{
+			"/api/v3/ticker/24hr": 80,
+			"/api/v3/depth":       5,
+			"/api/v3/trades":      25,
+		},
+		WeightHeader:   "X-MBX-USED-WEIGHT-1M",
+		MaxWeight:      maxWeight,
+		WeightInterval: time.Minute,
+	}
+}
+
+func init() {
+	Register(
+		Registration{
+			Name:      ProviderBinance,
+			Defaults:  binanceDefaultEndpoints,
+			New:       withoutDb(NewBinanceProvider),
+			Websocket: true,
+		},
+		Registration{
+			Name:      ProviderBinanceUS,
+			Defaults:  binanceUSDefaultEndpoints,
+			New:       withoutDb(NewBinanceProvider),
+			Websocket: true,
+		},
+	)
+}
+
 func NewBinanceProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -55,9 +139,11 @@ func NewBinanceProvider(
 		endpoints,
 		logger,
 		pairs,
-		nil,
-		nil,
+		provider.messageReceived,
+		provider.getSubscriptionMsgs,
 	)
+	provider.setOrderBookHandler(provider.getOrderBook)
+	provider.setTradeHandler(provider.getTrades, true)
 
 	if endpoints.Name == ProviderBinance {
 		// Add some failover URLs in random order for Binance global,
@@ -81,7 +167,8 @@ func NewBinanceProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToBinanceSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startWebsocket()
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -108,7 +195,6 @@ func (p *BinanceProvider) Poll() error {
 
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
-	now := time.Now()
 
 	for _, ticker := range tickers {
 		if !p.isPair(ticker.Symbol) {
@@ -119,7 +205,7 @@ func (p *BinanceProvider) Poll() error {
 			ticker.Symbol,
 			strToDec(ticker.LastPrice),
 			strToDec(ticker.Volume),
-			now,
+			unixMilliOrNow(ticker.CloseTime),
 		)
 	}
 
@@ -127,6 +213,124 @@ func (p *BinanceProvider) Poll() error {
 	return nil
 }
 
+func (p *BinanceProvider) getOrderBook(symbol string, depth int) (OrderBook, error) {
+	path := fmt.Sprintf("/api/v3/depth?symbol=%s&limit=%d", symbol, depth)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	var book BinanceOrderBook
+	err = json.Unmarshal(content, &book)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	return NewOrderBook(book.Bids, book.Asks)
+}
+
+func (p *BinanceProvider) getTrades(symbol string) ([]Trade, error) {
+	path := fmt.Sprintf("/api/v3/trades?symbol=%s&limit=1000", symbol)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var binanceTrades []BinanceTrade
+	err = json.Unmarshal(content, &binanceTrades)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]Trade, len(binanceTrades))
+	for i, trade := range binanceTrades {
+		trades[i] = Trade{
+			ID:     strconv.FormatInt(trade.ID, 10),
+			Price:  strToDec(trade.Price),
+			Amount: strToDec(trade.Qty),
+			Time:   time.UnixMilli(trade.Time),
+		}
+	}
+
+	return trades, nil
+}
+
+func (p *BinanceProvider) messageReceived(messageType int, bz []byte) {
+	var ticker BinanceWsTicker
+	err := json.Unmarshal(bz, &ticker)
+	if err != nil {
+		return
+	}
+
+	if ticker.Event == "trade" {
+		p.tradeReceived(bz)
+		return
+	}
+
+	if ticker.Event != "24hrMiniTicker" {
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !p.isPair(ticker.Symbol) {
+		return
+	}
+
+	p.setTickerPrice(
+		ticker.Symbol,
+		strToDec(ticker.LastPrice),
+		strToDec(ticker.Volume),
+		unixMilliOrNow(ticker.EventTime),
+	)
+}
+
+func (p *BinanceProvider) tradeReceived(bz []byte) {
+	var trade BinanceWsTrade
+	err := json.Unmarshal(bz, &trade)
+	if err != nil {
+		p.logger.Debug().Err(err).Msg("failed to unmarshal trade")
+		return
+	}
+
+	telemetryWebsocketMessage(p.endpoints.Name, MessageTypeTrade)
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !p.isPair(trade.Symbol) {
+		return
+	}
+
+	p.setTrades(trade.Symbol, Trade{
+		ID:     strconv.FormatInt(trade.ID, 10),
+		Price:  strToDec(trade.Price),
+		Amount: strToDec(trade.Qty),
+		Time:   time.UnixMilli(trade.Time),
+	})
+}
+
+func (p *BinanceProvider) getSubscriptionMsgs(pairs ...types.CurrencyPair) []interface{} {
+	stream := "@miniTicker"
+	if p.isTradeMode() {
+		stream = "@trade"
+	}
+
+	params := []string{}
+	for _, symbol := range p.websocketSymbols(pairs...) {
+		params = append(params, strings.ToLower(symbol)+stream)
+	}
+
+	return []interface{}{
+		BinanceSubscriptionMsg{
+			Method: "SUBSCRIBE",
+			Params: params,
+			ID:     1,
+		},
+	}
+}
+
 func (p *BinanceProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	tickers, err := p.getTickers()
 	if err != nil {
@@ -36,6 +36,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderBingx,
+			Defaults: bingxDefaultEndpoints,
+			New:      withoutDb(NewBingxProvider),
+		},
+	)
+}
+
 func NewBingxProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -55,7 +65,7 @@ func NewBingxProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToBingxSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
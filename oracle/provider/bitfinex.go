@@ -30,6 +30,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderBitfinex,
+			Defaults: bitfinexDefaultEndpoints,
+			New:      withoutDb(NewBitfinexProvider),
+		},
+	)
+}
+
 func NewBitfinexProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -49,7 +59,7 @@ func NewBitfinexProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToBitfinexSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
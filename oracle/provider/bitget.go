@@ -43,6 +43,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderBitget,
+			Defaults: bitgetDefaultEndpoints,
+			New:      withoutDb(NewBitgetProvider),
+		},
+	)
+}
+
 func NewBitgetProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -62,7 +72,7 @@ func NewBitgetProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToBitgetSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
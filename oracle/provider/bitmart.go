@@ -44,6 +44,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderBitmart,
+			Defaults: bitmartDefaultEndpoints,
+			New:      withoutDb(NewBitmartProvider),
+		},
+	)
+}
+
 func NewBitmartProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -63,7 +73,7 @@ func NewBitmartProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToBitmartSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
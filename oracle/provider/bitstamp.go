@@ -35,6 +35,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderBitstamp,
+			Defaults: bitstampDefaultEndpoints,
+			New:      withoutDb(NewBitstampProvider),
+		},
+	)
+}
+
 func NewBitstampProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -54,7 +64,7 @@ func NewBitstampProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToBitstampSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
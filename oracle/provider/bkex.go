@@ -40,6 +40,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderBkex,
+			Defaults: bkexDefaultEndpoints,
+			New:      withoutDb(NewBkexProvider),
+		},
+	)
+}
+
 func NewBkexProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -59,7 +69,7 @@ func NewBkexProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToBkexSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -3,19 +3,28 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"price-feeder/oracle/types"
 
+	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
 )
 
 var (
 	_                     Provider = (*BybitProvider)(nil)
 	bybitDefaultEndpoints          = Endpoint{
-		Name:         ProviderBybit,
-		Urls:         []string{"https://api.bybit.com", "https://api.bytick.com"},
-		PollInterval: 2 * time.Second,
+		Name:          ProviderBybit,
+		Urls:          []string{"https://api.bybit.com", "https://api.bytick.com"},
+		PollInterval:  2 * time.Second,
+		Websocket:     "stream.bybit.com",
+		WebsocketPath: "/v5/public/spot",
+		PingDuration:  defaultPingDuration,
+		PingType:      websocket.TextMessage,
+		PingMessage:   `{"op":"ping"}`,
 	}
 )
 
@@ -30,6 +39,7 @@ type (
 
 	BybitTickersResponse struct {
 		Result BybitTickersResult `json:"result"`
+		Time   int64              `json:"time"` // ex.: 1673859087947
 	}
 
 	BybitTickersResult struct {
@@ -41,8 +51,69 @@ type (
 		Price  string `json:"lastPrice"` // ex.: "21127.86"
 		Volume string `json:"volume24h"` // ex.: "211.378621"
 	}
+
+	BybitWsTickerResponse struct {
+		Topic string      `json:"topic"` // ex.: "tickers.BTCUSDT"
+		Data  BybitTicker `json:"data"`
+	}
+
+	BybitTradesResponse struct {
+		Result BybitTradesResult `json:"result"`
+	}
+
+	BybitTradesResult struct {
+		List []BybitTrade `json:"list"`
+	}
+
+	BybitTrade struct {
+		ID    string `json:"execId"` // ex.: "2100000000007764263"
+		Price string `json:"price"`  // ex.: "16618.49"
+		Size  string `json:"size"`   // ex.: "0.00012"
+		Time  string `json:"time"`   // ex.: "1672052955758"
+	}
+
+	// BybitWsTrade requires the side field, json keys are case insensitive
+	// and "S" would otherwise be decoded into the symbol.
+	BybitWsTrade struct {
+		ID     string `json:"i"` // ex.: "2290000000061666327"
+		Symbol string `json:"s"` // ex.: "BTCUSDT"
+		Side   string `json:"S"` // ex.: "Buy"
+		Price  string `json:"p"` // ex.: "16578.50"
+		Size   string `json:"v"` // ex.: "0.001"
+		Time   int64  `json:"T"` // ex.: 1672304486865
+	}
+
+	BybitWsTradeResponse struct {
+		Topic string         `json:"topic"` // ex.: "publicTrade.BTCUSDT"
+		Data  []BybitWsTrade `json:"data"`
+	}
+
+	BybitOrderBookResponse struct {
+		Result BybitOrderBook `json:"result"`
+	}
+
+	BybitOrderBook struct {
+		Bids [][]json.RawMessage `json:"b"` // ex.: [["65485.47", "47.081829"]]
+		Asks [][]json.RawMessage `json:"a"` // ex.: [["65557.7", "16.606555"]]
+	}
+
+	BybitSubscriptionMsg struct {
+		Op   string   `json:"op"`   // subscribe
+		Args []string `json:"args"` // ex.: ["tickers.BTCUSDT"]
+	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:      ProviderBybit,
+			Defaults:  bybitDefaultEndpoints,
+			New:       withoutDb(NewBybitProvider),
+			Websocket: true,
+		},
+	)
+}
+
 func NewBybitProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -55,14 +126,17 @@ func NewBybitProvider(
 		endpoints,
 		logger,
 		pairs,
-		nil,
-		nil,
+		provider.messageReceived,
+		provider.getSubscriptionMsgs,
 	)
+	provider.setOrderBookHandler(provider.getOrderBook)
+	provider.setTradeHandler(provider.getTrades, true)
 
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToBybitSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startWebsocket()
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -87,7 +161,7 @@ func (p *BybitProvider) Poll() error {
 		return err
 	}
 
-	timestamp := time.Now()
+	timestamp := unixMilliOrNow(tickersResponse.Time)
 
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -108,6 +182,135 @@ func (p *BybitProvider) Poll() error {
 	return nil
 }
 
+func (p *BybitProvider) getOrderBook(symbol string, depth int) (OrderBook, error) {
+	path := fmt.Sprintf("/v5/market/orderbook?category=spot&symbol=%s&limit=%d", symbol, depth)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	var response BybitOrderBookResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	return NewOrderBook(response.Result.Bids, response.Result.Asks)
+}
+
+func (p *BybitProvider) getTrades(symbol string) ([]Trade, error) {
+	path := fmt.Sprintf("/v5/market/recent-trade?category=spot&symbol=%s&limit=60", symbol)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response BybitTradesResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := []Trade{}
+	for _, trade := range response.Result.List {
+		timestamp, err := strconv.ParseInt(trade.Time, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		trades = append(trades, Trade{
+			ID:     trade.ID,
+			Price:  strToDec(trade.Price),
+			Amount: strToDec(trade.Size),
+			Time:   time.UnixMilli(timestamp),
+		})
+	}
+
+	return trades, nil
+}
+
+func (p *BybitProvider) messageReceived(messageType int, bz []byte) {
+	var response BybitWsTickerResponse
+	err := json.Unmarshal(bz, &response)
+
+	// trade messages fail to decode as tickers, but the topic is still set
+	if strings.HasPrefix(response.Topic, "publicTrade.") {
+		p.tradeReceived(bz)
+		return
+	}
+
+	if err != nil || !strings.HasPrefix(response.Topic, "tickers.") {
+		return
+	}
+
+	ticker := response.Data
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !p.isPair(ticker.Symbol) {
+		return
+	}
+
+	p.setTickerPrice(
+		ticker.Symbol,
+		strToDec(ticker.Price),
+		strToDec(ticker.Volume),
+		time.Now(),
+	)
+}
+
+func (p *BybitProvider) tradeReceived(bz []byte) {
+	var response BybitWsTradeResponse
+	err := json.Unmarshal(bz, &response)
+	if err != nil {
+		p.logger.Debug().Err(err).Msg("failed to unmarshal trades")
+		return
+	}
+
+	telemetryWebsocketMessage(p.endpoints.Name, MessageTypeTrade)
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, trade := range response.Data {
+		if !p.isPair(trade.Symbol) {
+			continue
+		}
+
+		p.setTrades(trade.Symbol, Trade{
+			ID:     trade.ID,
+			Price:  strToDec(trade.Price),
+			Amount: strToDec(trade.Size),
+			Time:   time.UnixMilli(trade.Time),
+		})
+	}
+}
+
+func (p *BybitProvider) getSubscriptionMsgs(pairs ...types.CurrencyPair) []interface{} {
+	topic := "tickers."
+	if p.isTradeMode() {
+		topic = "publicTrade."
+	}
+
+	// spot subscriptions are limited to 10 args per request
+	msgs := []interface{}{}
+	args := []string{}
+	for _, symbol := range p.websocketSymbols(pairs...) {
+		args = append(args, topic+symbol)
+		if len(args) == 10 {
+			msgs = append(msgs, BybitSubscriptionMsg{Op: "subscribe", Args: args})
+			args = []string{}
+		}
+	}
+
+	if len(args) > 0 {
+		msgs = append(msgs, BybitSubscriptionMsg{Op: "subscribe", Args: args})
+	}
+
+	return msgs
+}
+
 func (p *BybitProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	tickers, err := p.getTickers()
 	if err != nil {
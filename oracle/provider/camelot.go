@@ -44,9 +44,29 @@ type (
 		//   ["int256", "int256", "uint160", "uint128", "int24"]
 		topics   map[string][]string
 		decimals map[string]uint64
+		tokens   map[string][2]string
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:          ProviderCamelotV2,
+			Defaults:      camelotV2DefaultEndpoints,
+			New:           withDb(NewCamelotProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderCamelotV3,
+			Defaults:      camelotV3DefaultEndpoints,
+			New:           withDb(NewCamelotProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+	)
+}
+
 func NewCamelotProvider(
 	db *sql.DB,
 	ctx context.Context,
@@ -86,7 +106,7 @@ func NewCamelotProvider(
 
 	provider.init()
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -145,7 +165,11 @@ func (p *CamelotProvider) Poll() error {
 		"uint16", "uint8", "uint8", "bool",
 	}
 
-	timestamp := time.Now()
+	timestamp, err := p.getEvmBlockTime()
+	if err != nil {
+		p.logger.Warn().Err(err).Msg("failed to get block time")
+		timestamp = time.Now()
+	}
 
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -217,6 +241,27 @@ func (p *CamelotProvider) Poll() error {
 			volume,
 			timestamp,
 		)
+
+		tokens, found := p.tokens[contract]
+		if !found {
+			continue
+		}
+
+		liquidity, err := p.getEvmPoolLiquidity(
+			contract,
+			tokens,
+			[2]uint64{decimals1, decimals2},
+			price,
+		)
+		if err != nil {
+			p.logger.Warn().
+				Err(err).
+				Str("symbol", symbol).
+				Msg("failed to get pool liquidity")
+			continue
+		}
+
+		p.setTickerLiquidity(symbol, liquidity)
 	}
 
 	return nil
@@ -224,6 +269,7 @@ func (p *CamelotProvider) Poll() error {
 
 func (p *CamelotProvider) init() error {
 	p.decimals = map[string]uint64{}
+	p.tokens = map[string][2]string{}
 	types := []string{"address"}
 
 	for symbol, pair := range p.getAllPairs() {
@@ -237,6 +283,7 @@ func (p *CamelotProvider) init() error {
 		}
 
 		decimals := make([]uint64, 2)
+		tokens := [2]string{}
 
 		for i, method := range []string{"token0()", "token1()"} {
 			response, err := p.evmCall(contract, method, nil)
@@ -255,6 +302,7 @@ func (p *CamelotProvider) init() error {
 				return p.error(err)
 			}
 			token := fmt.Sprintf("%v", decoded[0])
+			tokens[i] = token
 
 			decimals[i], err = p.getEthDecimals(token)
 			if err != nil {
@@ -264,6 +312,7 @@ func (p *CamelotProvider) init() error {
 
 		p.decimals[pair.Base] = decimals[0]
 		p.decimals[pair.Quote] = decimals[1]
+		p.tokens[contract] = tokens
 	}
 
 	return nil
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+)
+
+const chainlinkDefaultHeartbeat = 24 * time.Hour
+
+var (
+	_                         Provider = (*ChainlinkProvider)(nil)
+	chainlinkDefaultEndpoints          = Endpoint{
+		Name:         ProviderChainlink,
+		Urls:         []string{},
+		PollInterval: 10 * time.Second,
+	}
+)
+
+type (
+	// ChainlinkProvider defines an oracle provider calling chainlink
+	// aggregator proxies on evm chains. The maximum age of a feed
+	// is set by the heartbeats of the endpoint, rounds older than the
+	// heartbeat are rejected.
+	//
+	// REF: https://docs.chain.link/data-feeds/api-reference
+	ChainlinkProvider struct {
+		provider
+		decimals map[string]uint64
+	}
+)
+
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderChainlink,
+			Defaults: chainlinkDefaultEndpoints,
+			New:      withoutDb(NewChainlinkProvider),
+		},
+	)
+}
+
+func NewChainlinkProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*ChainlinkProvider, error) {
+	provider := &ChainlinkProvider{}
+	provider.Init(
+		ctx,
+		endpoints,
+		logger,
+		pairs,
+		nil,
+		nil,
+	)
+
+	availablePairs, _ := provider.GetAvailablePairs()
+	provider.setPairs(pairs, availablePairs, nil)
+
+	provider.decimals = map[string]uint64{}
+
+	provider.startPolling(provider)
+	return provider, nil
+}
+
+func (p *ChainlinkProvider) Poll() error {
+	p.mtx.RLock()
+	pairs := p.getAllPairs()
+	p.mtx.RUnlock()
+
+	for symbol, pair := range pairs {
+		contract, err := p.getContractAddress(pair)
+		if err != nil {
+			p.logger.Warn().
+				Str("symbol", symbol).
+				Msg("no contract address found")
+			continue
+		}
+
+		decimals, found := p.decimals[contract]
+		if !found {
+			decimals, err = p.getEthDecimals(contract)
+			if err != nil {
+				p.logger.Err(err).Str("symbol", symbol).Msg("failed to get decimals")
+				continue
+			}
+			p.decimals[contract] = decimals
+		}
+
+		answer, updatedAt, err := p.latestRoundData(contract)
+		if err != nil {
+			p.logger.Err(err).Str("symbol", symbol).Msg("failed to get round data")
+			continue
+		}
+
+		heartbeat := p.heartbeat(symbol)
+		if time.Since(updatedAt) > heartbeat {
+			p.logger.Warn().
+				Str("symbol", symbol).
+				Time("updated_at", updatedAt).
+				Dur("heartbeat", heartbeat).
+				Msg("round is older than heartbeat")
+			continue
+		}
+
+		price := answer.Quo(uintToDec(10).Power(decimals))
+
+		p.locked(func() {
+			p.setTickerPrice(
+				symbol,
+				price,
+				math.LegacyZeroDec(),
+				updatedAt,
+			)
+		})
+	}
+
+	p.logger.Debug().Msg("updated tickers")
+	return nil
+}
+
+// latestRoundData returns the answer and update time of the latest round.
+func (p *ChainlinkProvider) latestRoundData(contract string) (math.LegacyDec, time.Time, error) {
+	response, err := p.evmCall(contract, "latestRoundData()", nil)
+	if err != nil {
+		return math.LegacyDec{}, time.Time{}, err
+	}
+
+	var data string
+	err = json.Unmarshal(response, &data)
+	if err != nil {
+		return math.LegacyDec{}, time.Time{}, p.error(err)
+	}
+
+	// roundId, answer, startedAt, updatedAt, answeredInRound
+	types := []string{"uint80", "int256", "uint256", "uint256", "uint80"}
+
+	decoded, err := decodeEthData(data, types)
+	if err != nil {
+		return math.LegacyDec{}, time.Time{}, p.error(err)
+	}
+
+	answer := strToDec(fmt.Sprintf("%v", decoded[1]))
+	if answer.IsNil() {
+		return math.LegacyDec{}, time.Time{}, p.errorf("failed parsing answer")
+	}
+
+	updatedAt, err := strconv.ParseInt(fmt.Sprintf("%v", decoded[3]), 10, 64)
+	if err != nil {
+		return math.LegacyDec{}, time.Time{}, p.error(err)
+	}
+
+	return answer, time.Unix(updatedAt, 0), nil
+}
+
+func (p *ChainlinkProvider) heartbeat(symbol string) time.Duration {
+	heartbeat, found := p.endpoints.Heartbeats[symbol]
+	if !found || heartbeat <= 0 {
+		return chainlinkDefaultHeartbeat
+	}
+	return heartbeat
+}
+
+// GetTickerPrices returns the latest rounds that are within the heartbeat
+// of their feed, rounds are only updated once per heartbeat or deviation
+// and are not stale after the default cutoff.
+func (p *ChainlinkProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	tickers := make(map[string]types.TickerPrice, len(pairs))
+	for _, pair := range pairs {
+		symbol := pair.String()
+
+		ticker, found := p.tickers[symbol]
+		if !found {
+			p.logger.Warn().Str("pair", symbol).Msg("missing ticker price for pair")
+			continue
+		}
+
+		// heartbeats are configured for the symbol of the feed
+		feed := symbol
+		if _, found := p.pairs[symbol]; !found {
+			feed = pair.Quote + pair.Base
+		}
+
+		if time.Since(ticker.Time) > p.heartbeat(feed) {
+			p.logger.Warn().
+				Str("pair", symbol).
+				Time("time", ticker.Time).
+				Msg("round is older than heartbeat")
+			continue
+		}
+
+		tickers[symbol] = ticker
+	}
+
+	return tickers, nil
+}
+
+func (p *ChainlinkProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	return p.getAvailablePairsFromContracts()
+}
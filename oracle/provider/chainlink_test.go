@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainlinkProvider_GetTickerPrices(t *testing.T) {
+	ethFeed := "0x5f4ec3df9cbd43714fe2740f5e3616155c5b8419"
+	btcFeed := "0xf4030086522a5beea4988f8ca5b36dbc97bee88c"
+
+	updatedAt := map[string]int64{
+		ethFeed: time.Now().Add(-time.Minute).Unix(),
+		// older than the heartbeat
+		btcFeed: time.Now().Add(-2 * time.Hour).Unix(),
+	}
+
+	decimalsHash, err := keccak256("decimals()")
+	require.NoError(t, err)
+	roundHash, err := keccak256("latestRoundData()")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var request struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+
+		var call struct {
+			To   string `json:"to"`
+			Data string `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(request.Params[0], &call))
+
+		var result string
+		switch strings.TrimPrefix(call.Data, "0x")[:8] {
+		case decimalsHash[:8]:
+			result = fmt.Sprintf("%064x", 8)
+		case roundHash[:8]:
+			// roundId, answer, startedAt, updatedAt, answeredInRound
+			result = fmt.Sprintf(
+				"%064x%064x%064x%064x%064x",
+				1, 200012345678, updatedAt[call.To], updatedAt[call.To], 1,
+			)
+		}
+
+		fmt.Fprintf(rw, `{"jsonrpc":"2.0","id":1,"result":"0x%s"}`, result)
+	}))
+	defer server.Close()
+
+	ethUsd := types.CurrencyPair{Base: "ETH", Quote: "USD"}
+	btcUsd := types.CurrencyPair{Base: "BTC", Quote: "USD"}
+
+	endpoints := Endpoint{
+		Name:         ProviderChainlink,
+		Urls:         []string{server.URL},
+		PollInterval: 50 * time.Millisecond,
+		ContractAddresses: map[string]string{
+			"ETHUSD": ethFeed,
+			"BTCUSD": btcFeed,
+		},
+		Heartbeats: map[string]time.Duration{
+			"ETHUSD": time.Hour,
+			"BTCUSD": time.Hour,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, err := NewChainlinkProvider(ctx, zerolog.Nop(), endpoints, ethUsd, btcUsd)
+	require.NoError(t, err)
+
+	var prices map[string]types.TickerPrice
+	require.Eventually(t, func() bool {
+		prices, err = p.GetTickerPrices(ethUsd, btcUsd)
+		return err == nil && len(prices) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Len(t, prices, 1)
+
+	ticker := prices["ETHUSD"]
+	require.Equal(t, math.LegacyMustNewDecFromStr("2000.12345678"), ticker.Price)
+	require.Equal(t, updatedAt[ethFeed], ticker.Time.Unix())
+}
@@ -4,18 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"price-feeder/oracle/types"
 
+	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
 )
 
 var (
 	_                        Provider = (*CoinbaseProvider)(nil)
 	coinbaseDefaultEndpoints          = Endpoint{
-		Name: ProviderCoinbase,
-		Urls: []string{"https://api.exchange.coinbase.com"},
+		Name:         ProviderCoinbase,
+		Urls:         []string{"https://api.exchange.coinbase.com"},
+		Websocket:    "ws-feed.exchange.coinbase.com",
+		PingDuration: defaultPingDuration,
+		PingType:     websocket.PingMessage,
 	}
 )
 
@@ -37,8 +42,46 @@ type (
 	CoinbaseTradingPair struct {
 		Symbol string `json:"id"` // ex.: "ADA-BTC"
 	}
+
+	CoinbaseWsTicker struct {
+		Type   string `json:"type"`       // ex.: "ticker"
+		Symbol string `json:"product_id"` // ex.: "BTC-USD"
+		Price  string `json:"price"`      // ex.: "24014.11"
+		Volume string `json:"volume_24h"` // ex.: "7421.5009"
+	}
+
+	CoinbaseTrade struct {
+		ID     int64  `json:"trade_id"`   // ex.: 74
+		Symbol string `json:"product_id"` // ex.: "BTC-USD", only set by websocket
+		Type   string `json:"type"`       // ex.: "match", only set by websocket
+		Price  string `json:"price"`      // ex.: "10.00000000"
+		Size   string `json:"size"`       // ex.: "0.01000000"
+		Time   string `json:"time"`       // ex.: "2014-11-07T22:19:28.578544Z"
+	}
+
+	CoinbaseOrderBook struct {
+		Bids [][]json.RawMessage `json:"bids"` // ex.: [["24014.11", "0.5", 3]]
+		Asks [][]json.RawMessage `json:"asks"` // ex.: [["24014.12", "1.2", 1]]
+	}
+
+	CoinbaseSubscriptionMsg struct {
+		Type       string   `json:"type"`        // subscribe
+		ProductIDs []string `json:"product_ids"` // ex.: ["BTC-USD"]
+		Channels   []string `json:"channels"`    // ex.: ["ticker"]
+	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:      ProviderCoinbase,
+			Defaults:  coinbaseDefaultEndpoints,
+			New:       withoutDb(NewCoinbaseProvider),
+			Websocket: true,
+		},
+	)
+}
+
 func NewCoinbaseProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -51,22 +94,30 @@ func NewCoinbaseProvider(
 		endpoints,
 		logger,
 		pairs,
-		nil,
-		nil,
+		provider.messageReceived,
+		provider.getSubscriptionMsgs,
 	)
+	provider.setOrderBookHandler(provider.getOrderBook)
+	provider.setTradeHandler(provider.getTrades, true)
 
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToCoinbaseSymbol)
 
 	interval := time.Duration(len(provider.getAllPairs())/10*2+1) * time.Second
 
-	go startPolling(provider, interval, logger)
+	provider.startWebsocket()
+	provider.endpoints.PollInterval = interval
+	provider.startPolling(provider)
 	return provider, nil
 }
 
 func (p *CoinbaseProvider) Poll() error {
+	p.mtx.RLock()
+	pairs := p.getAllPairs()
+	p.mtx.RUnlock()
+
 	i := 0
-	for symbol, pair := range p.getAllPairs() {
+	for symbol, pair := range pairs {
 		go func(p *CoinbaseProvider, symbol string, pair types.CurrencyPair) {
 			path := fmt.Sprintf("/products/%s/ticker", symbol)
 			content, err := p.httpGet(path)
@@ -105,6 +156,134 @@ func (p *CoinbaseProvider) Poll() error {
 	return nil
 }
 
+func (p *CoinbaseProvider) getOrderBook(symbol string, depth int) (OrderBook, error) {
+	path := fmt.Sprintf("/products/%s/book?level=2", symbol)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	var book CoinbaseOrderBook
+	err = json.Unmarshal(content, &book)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	return NewOrderBook(book.Bids, book.Asks)
+}
+
+func (p *CoinbaseProvider) getTrades(symbol string) ([]Trade, error) {
+	path := fmt.Sprintf("/products/%s/trades?limit=1000", symbol)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var coinbaseTrades []CoinbaseTrade
+	err = json.Unmarshal(content, &coinbaseTrades)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := []Trade{}
+	for _, trade := range coinbaseTrades {
+		trade, err := trade.toTrade()
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, trade)
+	}
+
+	return trades, nil
+}
+
+func (t CoinbaseTrade) toTrade() (Trade, error) {
+	timestamp, err := time.Parse(time.RFC3339Nano, t.Time)
+	if err != nil {
+		return Trade{}, err
+	}
+
+	return Trade{
+		ID:     strconv.FormatInt(t.ID, 10),
+		Price:  strToDec(t.Price),
+		Amount: strToDec(t.Size),
+		Time:   timestamp,
+	}, nil
+}
+
+func (p *CoinbaseProvider) messageReceived(messageType int, bz []byte) {
+	var ticker CoinbaseWsTicker
+	err := json.Unmarshal(bz, &ticker)
+	if err != nil {
+		return
+	}
+
+	// the last match is sent after subscribing to the matches channel
+	if ticker.Type == "match" || ticker.Type == "last_match" {
+		p.tradeReceived(bz)
+		return
+	}
+
+	if ticker.Type != "ticker" {
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !p.isPair(ticker.Symbol) {
+		return
+	}
+
+	p.setTickerPrice(
+		ticker.Symbol,
+		strToDec(ticker.Price),
+		strToDec(ticker.Volume),
+		time.Now(),
+	)
+}
+
+func (p *CoinbaseProvider) tradeReceived(bz []byte) {
+	var coinbaseTrade CoinbaseTrade
+	err := json.Unmarshal(bz, &coinbaseTrade)
+	if err != nil {
+		p.logger.Debug().Err(err).Msg("failed to unmarshal trade")
+		return
+	}
+
+	telemetryWebsocketMessage(p.endpoints.Name, MessageTypeTrade)
+
+	trade, err := coinbaseTrade.toTrade()
+	if err != nil {
+		p.logger.Err(err).Msg("failed parsing timestamp")
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !p.isPair(coinbaseTrade.Symbol) {
+		return
+	}
+
+	p.setTrades(coinbaseTrade.Symbol, trade)
+}
+
+func (p *CoinbaseProvider) getSubscriptionMsgs(pairs ...types.CurrencyPair) []interface{} {
+	channel := "ticker"
+	if p.isTradeMode() {
+		channel = "matches"
+	}
+
+	return []interface{}{
+		CoinbaseSubscriptionMsg{
+			Type:       "subscribe",
+			ProductIDs: p.websocketSymbols(pairs...),
+			Channels:   []string{channel},
+		},
+	}
+}
+
 func (p *CoinbaseProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	content, err := p.httpGet("/products")
 	if err != nil {
@@ -40,6 +40,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderCoinex,
+			Defaults: coinexDefaultEndpoints,
+			New:      withoutDb(NewCoinexProvider),
+		},
+	)
+}
+
 func NewCoinexProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -59,7 +69,7 @@ func NewCoinexProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, nil)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
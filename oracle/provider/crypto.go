@@ -45,6 +45,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderCrypto,
+			Defaults: cryptoDefaultEndpoints,
+			New:      withoutDb(NewCryptoProvider),
+		},
+	)
+}
+
 func NewCryptoProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -64,7 +74,7 @@ func NewCryptoProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToCryptoSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
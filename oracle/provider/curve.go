@@ -2,15 +2,26 @@ package provider
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
 	"price-feeder/oracle/types"
 
+	"cosmossdk.io/math"
 	"github.com/rs/zerolog"
 )
 
+const (
+	// curveEthAddress is used by curve pools for the native ether coin
+	curveEthAddress = "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"
+	curveMaxCoins   = 8
+)
+
 var (
 	_                     Provider = (*CurveProvider)(nil)
 	curveDefaultEndpoints          = Endpoint{
@@ -22,11 +33,21 @@ var (
 
 type (
 	// CurveProvider defines an oracle provider implemented by the curve.fi
-	// public API.
+	// public API. If contract addresses are configured, the pools are called
+	// directly on an evm rpc instead, using price_oracle where available and
+	// get_dy otherwise, with volumes from TokenExchange logs.
 	//
 	// REF: https://github.com/curvefi/curve-api
+	// REF: https://docs.curve.fi/stableswap-exchange/stableswap-ng/pools/plainpool/
 	CurveProvider struct {
 		provider
+		onChain bool
+		// map topic hash to output types of TokenExchange events
+		topics   map[string][]string
+		indices  map[string][2]int64
+		decimals map[string][2]uint64
+		tokens   map[string][2]string
+		oracles  map[string]bool
 	}
 
 	CurvePoolsResponse struct {
@@ -64,13 +85,27 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:          ProviderCurve,
+			Defaults:      curveDefaultEndpoints,
+			New:           withDb(NewCurveProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+	)
+}
+
 func NewCurveProvider(
+	db *sql.DB,
 	ctx context.Context,
 	logger zerolog.Logger,
 	endpoints Endpoint,
 	pairs ...types.CurrencyPair,
 ) (*CurveProvider, error) {
 	provider := &CurveProvider{}
+	provider.db = db
 	provider.Init(
 		ctx,
 		endpoints,
@@ -80,14 +115,50 @@ func NewCurveProvider(
 		nil,
 	)
 
+	provider.onChain = len(provider.endpoints.ContractAddresses) > 0
+
+	if provider.onChain {
+		provider.chain = provider.endpoints.Chain
+		if provider.chain == "" {
+			provider.chain = "ethereum"
+		}
+		provider.name = provider.endpoints.Name.String()
+
+		provider.topics = map[string][]string{}
+		for _, values := range [][]string{{
+			"TokenExchange(address,int128,uint256,int128,uint256)",
+			"int128", "uint256", "int128", "uint256",
+		}, {
+			"TokenExchange(address,uint256,uint256,uint256,uint256)",
+			"uint256", "uint256", "uint256", "uint256",
+		}, {
+			"TokenExchange(address,uint256,uint256,uint256,uint256,uint256,uint256)",
+			"uint256", "uint256", "uint256", "uint256", "uint256", "uint256",
+		}} {
+			topic, err := keccak256(values[0])
+			if err != nil {
+				return nil, err
+			}
+			provider.topics["0x"+topic] = values[1:]
+		}
+	}
+
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, nil)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	if provider.onChain {
+		provider.setCoins()
+	}
+
+	provider.startPolling(provider)
 	return provider, nil
 }
 
 func (p *CurveProvider) Poll() error {
+	if p.onChain {
+		return p.pollContracts()
+	}
+
 	// get subgraph data, which provides 24h volume data
 	// https://api.curve.fi/api/getSubgraphData/ethereum
 
@@ -165,6 +236,10 @@ func (p *CurveProvider) Poll() error {
 }
 
 func (p *CurveProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	if p.onChain {
+		return p.getAvailablePairsFromContracts()
+	}
+
 	symbols := map[string]struct{}{}
 
 	for _, registryID := range []string{"main", "crypto", "factory"} {
@@ -190,3 +265,377 @@ func (p *CurveProvider) GetAvailablePairs() (map[string]struct{}, error) {
 
 	return symbols, nil
 }
+
+func (p *CurveProvider) pollContracts() error {
+	p.mtx.RLock()
+	pairs := p.getAllPairs()
+	p.mtx.RUnlock()
+
+	contracts := []string{}
+	for symbol := range pairs {
+		contract, found := p.contracts[symbol]
+		if !found {
+			continue
+		}
+		contracts = append(contracts, contract)
+	}
+
+	err := p.updateEvmVolumes(func(from, to uint64) error {
+		return p.updateVolumes(from, to, contracts)
+	})
+	if err != nil {
+		p.logger.Warn().Err(err).Msg("failed to update volumes")
+	}
+
+	timestamp := time.Now()
+
+	for _, contract := range contracts {
+		symbol := p.contracts[contract]
+
+		// pair in order of the configured contract symbol
+		p.mtx.RLock()
+		pair, found := p.getPair(symbol)
+		p.mtx.RUnlock()
+		if !found {
+			continue
+		}
+
+		if _, found := p.indices[contract]; !found {
+			p.logger.Warn().Str("symbol", symbol).Msg("coins not found")
+			continue
+		}
+
+		price, err := p.getPrice(contract)
+		if err != nil {
+			p.logger.Err(err).Str("symbol", symbol).Msg("failed to get price")
+			continue
+		}
+
+		volume, _ := p.volumes.Get(pair.String())
+
+		p.locked(func() {
+			p.setTickerPrice(symbol, price, volume, timestamp)
+		})
+
+		liquidity, err := p.getEvmPoolLiquidity(
+			contract,
+			p.tokens[contract],
+			p.decimals[contract],
+			price,
+		)
+		if err != nil {
+			p.logger.Warn().
+				Err(err).
+				Str("symbol", symbol).
+				Msg("failed to get pool liquidity")
+			continue
+		}
+
+		p.locked(func() {
+			p.setTickerLiquidity(symbol, liquidity)
+		})
+	}
+
+	p.logger.Debug().Msg("updated tickers")
+	return nil
+}
+
+// getPrice returns the price of the base coin in the quote coin of a pool,
+// preferring the price oracle of the pool over get_dy.
+func (p *CurveProvider) getPrice(contract string) (math.LegacyDec, error) {
+	indices := p.indices[contract]
+
+	if p.oracles[contract] {
+		prices := [2]math.LegacyDec{}
+		for i, index := range indices {
+			price, err := p.getOraclePrice(contract, index)
+			if err != nil {
+				return math.LegacyDec{}, err
+			}
+			prices[i] = price
+		}
+		if !prices[1].IsPositive() {
+			return math.LegacyDec{}, p.errorf("price oracle is zero")
+		}
+		return prices[0].Quo(prices[1]), nil
+	}
+
+	decimals := p.decimals[contract]
+	amount := new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(decimals[0]), nil)
+	args := []string{fmt.Sprintf("%064x%064x%064x", indices[0], indices[1], amount)}
+
+	decoded, err := p.callPool(
+		contract,
+		[]string{"get_dy(int128,int128,uint256)", "get_dy(uint256,uint256,uint256)"},
+		args,
+		[]string{"uint256"},
+	)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	dy := strToDec(fmt.Sprintf("%v", decoded[0]))
+	if dy.IsNil() {
+		return math.LegacyDec{}, p.errorf("failed parsing get_dy")
+	}
+
+	return dy.Quo(uintToDec(10).Power(decimals[1])), nil
+}
+
+// getOraclePrice returns the price of a coin in the first coin of the pool.
+// Pools with more than two coins take the index of the coin minus one,
+// older two coin crypto pools have no argument.
+func (p *CurveProvider) getOraclePrice(contract string, index int64) (math.LegacyDec, error) {
+	if index == 0 {
+		return math.LegacyOneDec(), nil
+	}
+
+	decoded, err := p.callPool(
+		contract,
+		[]string{"price_oracle(uint256)"},
+		[]string{fmt.Sprintf("%064x", index-1)},
+		[]string{"uint256"},
+	)
+	if err != nil && index == 1 {
+		decoded, err = p.callPool(contract, []string{"price_oracle()"}, nil, []string{"uint256"})
+	}
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	price := strToDec(fmt.Sprintf("%v", decoded[0]))
+	if price.IsNil() {
+		return math.LegacyDec{}, p.errorf("failed parsing price oracle")
+	}
+
+	return price.Quo(uintToDec(10).Power(18)), nil
+}
+
+// callPool calls the first of the method signatures that succeeds, the
+// signatures of curve pools differ between pool versions.
+func (p *CurveProvider) callPool(
+	contract string,
+	methods []string,
+	args []string,
+	types []string,
+) ([]interface{}, error) {
+	var err error
+	for _, method := range methods {
+		var response json.RawMessage
+		response, err = p.evmCall(contract, method, args)
+		if err != nil {
+			continue
+		}
+
+		var data string
+		err = json.Unmarshal(response, &data)
+		if err != nil {
+			continue
+		}
+
+		var decoded []interface{}
+		decoded, err = decodeEthData(data, types)
+		if err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, err
+}
+
+// setCoins finds the indices, token addresses and decimals of the base and
+// quote coins of all pool contracts by their token symbols.
+func (p *CurveProvider) setCoins() {
+	p.indices = map[string][2]int64{}
+	p.decimals = map[string][2]uint64{}
+	p.tokens = map[string][2]string{}
+	p.oracles = map[string]bool{}
+
+	for symbol := range p.getAllPairs() {
+		logger := p.logger.With().Str("symbol", symbol).Logger()
+
+		contract, found := p.contracts[symbol]
+		if !found {
+			logger.Warn().Msg("contract not found")
+			continue
+		}
+
+		pair, found := p.getPair(p.contracts[contract])
+		if !found {
+			continue
+		}
+
+		indices := [2]int64{-1, -1}
+		tokens := [2]string{}
+		decimals := [2]uint64{}
+
+		for k := int64(0); k < curveMaxCoins; k++ {
+			decoded, err := p.callPool(
+				contract,
+				[]string{"coins(uint256)", "coins(int128)"},
+				[]string{fmt.Sprintf("%064x", k)},
+				[]string{"address"},
+			)
+			if err != nil {
+				break
+			}
+			token := strings.ToLower(fmt.Sprintf("%v", decoded[0]))
+
+			denom, tokenDecimals, err := p.getToken(token)
+			if err != nil {
+				logger.Err(err).Str("token", token).Msg("failed to get token")
+				continue
+			}
+
+			for i, base := range []string{pair.Base, pair.Quote} {
+				if strings.EqualFold(base, denom) {
+					indices[i] = k
+					tokens[i] = token
+					decimals[i] = tokenDecimals
+				}
+			}
+		}
+
+		if indices[0] < 0 || indices[1] < 0 {
+			logger.Error().Msg("coins not found in pool")
+			continue
+		}
+
+		p.indices[contract] = indices
+		p.tokens[contract] = tokens
+		p.decimals[contract] = decimals
+
+		_, err := p.getOraclePrice(contract, 1)
+		p.oracles[contract] = err == nil
+	}
+}
+
+// getToken returns the symbol and decimals of a token.
+func (p *CurveProvider) getToken(token string) (string, uint64, error) {
+	if token == curveEthAddress {
+		return "ETH", 18, nil
+	}
+
+	response, err := p.evmCall(token, "symbol()", nil)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var data string
+	err = json.Unmarshal(response, &data)
+	if err != nil {
+		return "", 0, p.error(err)
+	}
+
+	decoded, err := decodeEthData(data, []string{"string"})
+	if err != nil {
+		return "", 0, p.error(err)
+	}
+
+	decimals, err := p.getEthDecimals(token)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return fmt.Sprintf("%v", decoded[0]), decimals, nil
+}
+
+func (p *CurveProvider) updateVolumes(
+	height1, height2 uint64,
+	addresses []string,
+) error {
+	if len(p.volumes.Symbols()) == 0 {
+		return nil
+	}
+
+	if height1 >= height2 {
+		return nil
+	}
+
+	volumes, err := p.newEvmVolumes(height1, height2)
+	if err != nil {
+		return err
+	}
+	height1 = height1 + 1
+
+	// the topics of a getLogs filter are positional, every event signature
+	// is queried separately
+	logs := []EvmLog{}
+	for topic := range p.topics {
+		topicLogs, err := p.evmGetLogs(height1, height2, addresses, []string{topic})
+		if err != nil {
+			return err
+		}
+		logs = append(logs, topicLogs...)
+	}
+
+	ten := int64ToDec(10)
+
+	for _, log := range logs {
+		symbol, found := p.contracts[log.Address]
+		if !found {
+			p.logger.Warn().Str("contract", log.Address).Msg("symbol not found")
+			continue
+		}
+
+		p.mtx.RLock()
+		pair, found := p.getPair(symbol)
+		p.mtx.RUnlock()
+		if !found {
+			continue
+		}
+
+		contract := p.contracts[symbol]
+		indices, found := p.indices[contract]
+		if !found {
+			continue
+		}
+		decimals := p.decimals[contract]
+
+		// sold_id, tokens_sold, bought_id, tokens_bought
+		data, err := decodeEthData(log.Data, p.topics[log.Topics[0]])
+		if err != nil {
+			return p.error(err)
+		}
+
+		sold, err := strconv.ParseInt(fmt.Sprintf("%v", data[0]), 10, 64)
+		if err != nil {
+			return p.error(err)
+		}
+		bought, err := strconv.ParseInt(fmt.Sprintf("%v", data[2]), 10, 64)
+		if err != nil {
+			return p.error(err)
+		}
+
+		// amounts of the base and quote coin
+		var amounts [2]string
+		switch {
+		case sold == indices[0] && bought == indices[1]:
+			amounts = [2]string{fmt.Sprintf("%v", data[1]), fmt.Sprintf("%v", data[3])}
+		case sold == indices[1] && bought == indices[0]:
+			amounts = [2]string{fmt.Sprintf("%v", data[3]), fmt.Sprintf("%v", data[1])}
+		default:
+			// exchange of other coins of the pool
+			continue
+		}
+
+		index := int(log.Height - height1)
+		if index >= len(volumes) || index < 0 {
+			return p.errorf("log height out of range")
+		}
+
+		symbols := []string{pair.String(), pair.Swap().String()}
+		for i, symbol := range symbols {
+			amount := strToDec(amounts[i]).Quo(ten.Power(decimals[i]))
+
+			current, found := volumes[index].Values[symbol]
+			if !found {
+				continue
+			}
+			volumes[index].Values[symbol] = current.Add(amount)
+		}
+	}
+
+	p.volumes.Add(volumes)
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurveProvider_PollContracts(t *testing.T) {
+	cryptoPool := "0x7f86bf177dd4f3494b841a37e810a34dd56c829b"
+	stablePool := "0xbebc44782c7db0a1a60cb6fe97d0b483032ff1c7"
+
+	usdc := fmt.Sprintf("0x%040x", 1)
+	dai := fmt.Sprintf("0x%040x", 2)
+
+	coins := map[string][]string{
+		cryptoPool: {usdc, curveEthAddress},
+		stablePool: {dai, usdc},
+	}
+	tokens := map[string]struct {
+		symbol   string
+		decimals int
+	}{
+		usdc: {"USDC", 6},
+		dai:  {"DAI", 18},
+	}
+
+	// abi encoded string of up to 32 bytes
+	encodeString := func(s string) string {
+		data := fmt.Sprintf("%x", s)
+		return fmt.Sprintf("%064x%064x%s", 32, len(s), data+strings.Repeat("0", 64-len(data)))
+	}
+
+	methods := map[string]string{}
+	for _, method := range []string{
+		"coins(uint256)", "symbol()", "decimals()",
+		"price_oracle(uint256)", "get_dy(int128,int128,uint256)",
+	} {
+		hash, err := keccak256(method)
+		require.NoError(t, err)
+		methods[hash[:8]] = method
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var request struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+
+		if request.Method == "eth_blockNumber" {
+			fmt.Fprint(rw, `{"jsonrpc":"2.0","id":1,"result":"0x10"}`)
+			return
+		}
+
+		var call struct {
+			To   string `json:"to"`
+			Data string `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(request.Params[0], &call))
+
+		data := strings.TrimPrefix(call.Data, "0x")
+		args := data[8:]
+
+		var result string
+		switch methods[data[:8]] {
+		case "coins(uint256)":
+			var k int
+			fmt.Sscanf(args, "%x", &k)
+			if k < len(coins[call.To]) {
+				result = fmt.Sprintf("%064s", coins[call.To][k][2:])
+			}
+		case "symbol()":
+			result = encodeString(tokens[call.To].symbol)
+		case "decimals()":
+			result = fmt.Sprintf("%064x", tokens[call.To].decimals)
+		case "price_oracle(uint256)":
+			// 3000 USDC per ETH
+			if call.To == cryptoPool {
+				result = fmt.Sprintf("%064x", math.NewIntWithDecimal(3000, 18).BigInt())
+			}
+		case "get_dy(int128,int128,uint256)":
+			// 0.999 USDC per DAI
+			if call.To == stablePool && args[:128] == fmt.Sprintf("%064x%064x", 0, 1) {
+				result = fmt.Sprintf("%064x", 999000)
+			}
+		}
+
+		if result == "" {
+			fmt.Fprint(rw, `{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted"}}`)
+			return
+		}
+		fmt.Fprintf(rw, `{"jsonrpc":"2.0","id":1,"result":"0x%s"}`, result)
+	}))
+	defer server.Close()
+
+	ethUsdc := types.CurrencyPair{Base: "ETH", Quote: "USDC"}
+	daiUsdc := types.CurrencyPair{Base: "DAI", Quote: "USDC"}
+
+	endpoints := Endpoint{
+		Name:         ProviderCurve,
+		Urls:         []string{server.URL},
+		PollInterval: 50 * time.Millisecond,
+		ContractAddresses: map[string]string{
+			"ETHUSDC": cryptoPool,
+			"DAIUSDC": stablePool,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, err := NewCurveProvider(nil, ctx, zerolog.Nop(), endpoints, ethUsdc, daiUsdc)
+	require.NoError(t, err)
+	require.True(t, p.oracles[cryptoPool])
+	require.False(t, p.oracles[stablePool])
+
+	var prices map[string]types.TickerPrice
+	require.Eventually(t, func() bool {
+		prices, err = p.GetTickerPrices(ethUsdc, daiUsdc)
+		return err == nil && len(prices) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.Equal(t, math.LegacyNewDec(3000), prices["ETHUSDC"].Price)
+	require.Equal(t, math.LegacyMustNewDecFromStr("0.999"), prices["DAIUSDC"].Price)
+}
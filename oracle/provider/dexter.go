@@ -33,6 +33,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderDexter,
+			Defaults: dexterDefaultEndpoints,
+			New:      withoutDb(NewDexterProvider),
+		},
+	)
+}
+
 func NewDexterProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -56,7 +66,7 @@ func NewDexterProvider(
 
 	provider.denoms = provider.getDenoms()
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -43,6 +43,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderFin,
+			Defaults: finDefaultEndpoints,
+			New:      withoutDb(NewFinProvider),
+		},
+	)
+}
+
 func NewFinProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -62,7 +72,7 @@ func NewFinProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToFinSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -56,6 +56,18 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:          ProviderFinV2,
+			Defaults:      finV2DefaultEndpoints,
+			New:           withDb(NewFinV2Provider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+	)
+}
+
 func NewFinV2Provider(
 	db *sql.DB,
 	ctx context.Context,
@@ -79,7 +91,7 @@ func NewFinV2Provider(
 
 	provider.delta = map[string]int64{}
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 
 	return provider, nil
 }
@@ -35,6 +35,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderGate,
+			Defaults: gateDefaultEndpoints,
+			New:      withoutDb(NewGateProvider),
+		},
+	)
+}
+
 func NewGateProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -54,7 +64,7 @@ func NewGateProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToGateSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+)
+
+const (
+	GenericRestTimestampUnix    = "unix"
+	GenericRestTimestampUnixMs  = "unix_ms"
+	GenericRestTimestampRFC3339 = "rfc3339"
+)
+
+var _ Provider = (*GenericRestProvider)(nil)
+
+type (
+	// GenericRestProvider defines an oracle provider that is configured
+	// entirely by GenericRestOptions, for simple REST APIs that return
+	// tickers as JSON.
+	GenericRestProvider struct {
+		provider
+		options GenericRestOptions
+	}
+
+	// GenericRestOptions defines how tickers are requested and extracted.
+	// Paths are dot separated keys or array indices, ex.: "data.0.last".
+	GenericRestOptions struct {
+		// Path of the request, "{symbol}" is replaced by the provider
+		// symbol for per symbol requests, ex.: "/ticker?symbol={symbol}"
+		Path string
+		// Symbol template with "{base}", "{quote}", "{base_lower}" and
+		// "{quote_lower}", ex.: "{base}-{quote}"
+		Symbol string
+		// Tickers is the path to the list or map of all tickers, only used
+		// if Path does not contain "{symbol}"
+		Tickers string
+		// SymbolField is the path to the symbol within a ticker of a list
+		SymbolField     string
+		Price           string
+		Volume          string
+		VolumeUnit      types.VolumeUnit
+		Timestamp       string
+		TimestampFormat string
+	}
+)
+
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderGenericRest,
+			Defaults: Endpoint{},
+			New:      withoutDb(NewGenericRestProvider),
+			Custom:   true,
+		},
+	)
+}
+
+func NewGenericRestProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*GenericRestProvider, error) {
+	if endpoints.Generic == nil {
+		return nil, fmt.Errorf("no generic rest options for %s", endpoints.Name)
+	}
+
+	provider := &GenericRestProvider{
+		options: *endpoints.Generic,
+	}
+	provider.Init(
+		ctx,
+		endpoints,
+		logger,
+		pairs,
+		nil,
+		nil,
+	)
+
+	availablePairs, _ := provider.GetAvailablePairs()
+	provider.setPairs(pairs, availablePairs, provider.currencyPairToSymbol)
+
+	provider.startPolling(provider)
+	return provider, nil
+}
+
+func (p *GenericRestProvider) Poll() error {
+	if !p.isPerSymbol() {
+		tickers, err := p.getTickers()
+		if err != nil {
+			return err
+		}
+
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+
+		for symbol, ticker := range tickers {
+			if !p.isPair(symbol) {
+				continue
+			}
+			p.setTicker(symbol, ticker)
+		}
+
+		p.logger.Debug().Msg("updated tickers")
+		return nil
+	}
+
+	// only the configured pairs are requested, the available pairs are
+	// unknown in per symbol mode and inverted symbols usually don't exist
+	p.mtx.RLock()
+	symbols := make([]string, 0, len(p.pairs))
+	for symbol := range p.pairs {
+		symbols = append(symbols, symbol)
+	}
+	p.mtx.RUnlock()
+
+	for _, symbol := range symbols {
+		path := strings.ReplaceAll(p.options.Path, "{symbol}", symbol)
+
+		ticker, err := p.getJson(path)
+		if err != nil {
+			p.logger.Err(err).Str("symbol", symbol).Msg("failed to get ticker")
+			continue
+		}
+
+		p.locked(func() {
+			p.setTicker(symbol, ticker)
+		})
+	}
+
+	p.logger.Debug().Msg("updated tickers")
+	return nil
+}
+
+func (p *GenericRestProvider) setTicker(symbol string, ticker interface{}) {
+	price, err := genericRestDec(ticker, p.options.Price)
+	if err != nil {
+		p.logger.Err(err).Str("symbol", symbol).Msg("failed to get price")
+		return
+	}
+
+	volume := math.LegacyZeroDec()
+	if p.options.Volume != "" {
+		volume, err = genericRestDec(ticker, p.options.Volume)
+		if err != nil {
+			p.logger.Err(err).Str("symbol", symbol).Msg("failed to get volume")
+			return
+		}
+	}
+
+	timestamp := time.Now()
+	if p.options.Timestamp != "" {
+		timestamp, err = genericRestTime(ticker, p.options.Timestamp, p.options.TimestampFormat)
+		if err != nil {
+			p.logger.Err(err).Str("symbol", symbol).Msg("failed to get timestamp")
+			return
+		}
+	}
+
+	p.setTickerPriceWithUnit(symbol, price, volume, p.options.VolumeUnit, timestamp)
+}
+
+// getTickers returns all tickers by provider symbol, the tickers are
+// either a list with a symbol field or a map keyed by symbol.
+func (p *GenericRestProvider) getTickers() (map[string]interface{}, error) {
+	content, err := p.getJson(p.options.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := genericRestValue(content, p.options.Tickers)
+	if err != nil {
+		return nil, err
+	}
+
+	tickers := map[string]interface{}{}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		tickers = value
+	case []interface{}:
+		for _, ticker := range value {
+			symbol, err := genericRestValue(ticker, p.options.SymbolField)
+			if err != nil {
+				continue
+			}
+			tickers[fmt.Sprint(symbol)] = ticker
+		}
+	default:
+		return nil, p.errorf("tickers are neither a list nor a map")
+	}
+
+	return tickers, nil
+}
+
+func (p *GenericRestProvider) getJson(path string) (interface{}, error) {
+	content, err := p.httpGet(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var value interface{}
+	err = decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (p *GenericRestProvider) isPerSymbol() bool {
+	return strings.Contains(p.options.Path, "{symbol}")
+}
+
+func (p *GenericRestProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	if p.isPerSymbol() {
+		return nil, nil
+	}
+
+	tickers, err := p.getTickers()
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := map[string]struct{}{}
+	for symbol := range tickers {
+		symbols[symbol] = struct{}{}
+	}
+
+	return symbols, nil
+}
+
+func (p *GenericRestProvider) currencyPairToSymbol(pair types.CurrencyPair) string {
+	template := p.options.Symbol
+	if template == "" {
+		template = "{base}{quote}"
+	}
+
+	return strings.NewReplacer(
+		"{base}", pair.Base,
+		"{quote}", pair.Quote,
+		"{base_lower}", strings.ToLower(pair.Base),
+		"{quote_lower}", strings.ToLower(pair.Quote),
+	).Replace(template)
+}
+
+// genericRestValue returns the value at the dot separated path.
+func genericRestValue(value interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return value, nil
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			next, found := current[key]
+			if !found {
+				return nil, fmt.Errorf("key %s not found in %s", key, path)
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, fmt.Errorf("invalid index %s in %s", key, path)
+			}
+			value = current[index]
+		default:
+			return nil, fmt.Errorf("cannot resolve %s in %s", key, path)
+		}
+	}
+
+	return value, nil
+}
+
+func genericRestDec(value interface{}, path string) (math.LegacyDec, error) {
+	value, err := genericRestValue(value, path)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	switch value := value.(type) {
+	case json.Number:
+		return math.LegacyNewDecFromStr(value.String())
+	case string:
+		return math.LegacyNewDecFromStr(value)
+	}
+
+	return math.LegacyDec{}, fmt.Errorf("%s is not a number", path)
+}
+
+func genericRestTime(value interface{}, path string, format string) (time.Time, error) {
+	value, err := genericRestValue(value, path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	str := fmt.Sprint(value)
+
+	switch format {
+	case GenericRestTimestampRFC3339:
+		return time.Parse(time.RFC3339, str)
+	case GenericRestTimestampUnix:
+		seconds, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(seconds, 0), nil
+	case "", GenericRestTimestampUnixMs:
+		milliseconds, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(milliseconds), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported timestamp format: %s", format)
+}
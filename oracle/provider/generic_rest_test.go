@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenericRestProvider_Poll(t *testing.T) {
+	atomUsd := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+	usdtAtom := types.CurrencyPair{Base: "USDT", Quote: "ATOM"}
+
+	t.Run("ticker_list", func(t *testing.T) {
+		now := time.Now()
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/api/v1/tickers" {
+				t.Errorf("unexpected path %s", req.URL.Path)
+				http.NotFound(rw, req)
+				return
+			}
+			fmt.Fprintf(rw, `{"data":[
+				{"symbol":"atom_usd","last":"10.5","vol":1000,"ts":%[1]d},
+				{"symbol":"atom_usdt","last":12.5,"vol":"2000","ts":%[1]d},
+				{"symbol":"btc_usd","last":"30000","vol":"1","ts":%[1]d}
+			]}`, now.UnixMilli())
+		}))
+		defer server.Close()
+
+		endpoints := Endpoint{
+			Name:         "example",
+			Urls:         []string{server.URL},
+			PollInterval: time.Minute,
+			Generic: &GenericRestOptions{
+				Path:        "/api/v1/tickers",
+				Symbol:      "{base_lower}_{quote_lower}",
+				Tickers:     "data",
+				SymbolField: "symbol",
+				Price:       "last",
+				Volume:      "vol",
+				Timestamp:   "ts",
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		p, err := NewGenericRestProvider(ctx, zerolog.Nop(), endpoints, atomUsd, usdtAtom)
+		require.NoError(t, err)
+		require.NoError(t, p.Poll())
+
+		prices, err := p.GetTickerPrices(atomUsd, usdtAtom)
+		require.NoError(t, err)
+		require.Equal(t, math.LegacyMustNewDecFromStr("10.5"), prices["ATOMUSD"].Price)
+		require.Equal(t, math.LegacyNewDec(1000), prices["ATOMUSD"].Volume)
+		require.Equal(t, time.UnixMilli(now.UnixMilli()), prices["ATOMUSD"].Time)
+
+		// inverted pair
+		require.Equal(t, math.LegacyMustNewDecFromStr("0.08"), prices["USDTATOM"].Price)
+		require.Len(t, prices, 2)
+	})
+
+	t.Run("per_symbol", func(t *testing.T) {
+		now := time.Now().UTC()
+
+		var mtx sync.Mutex
+		paths := map[string]struct{}{}
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			mtx.Lock()
+			paths[req.URL.Path] = struct{}{}
+			mtx.Unlock()
+			fmt.Fprintf(rw, `{"result":{"price":["10.5"],"volume":"20","time":"%s"}}`, now.Format(time.RFC3339))
+		}))
+		defer server.Close()
+
+		endpoints := Endpoint{
+			Name:         "example",
+			Urls:         []string{server.URL},
+			PollInterval: time.Minute,
+			Generic: &GenericRestOptions{
+				Path:            "/ticker/{symbol}",
+				Symbol:          "{base}-{quote}",
+				Price:           "result.price.0",
+				Volume:          "result.volume",
+				VolumeUnit:      types.VolumeUnitQuote,
+				Timestamp:       "result.time",
+				TimestampFormat: GenericRestTimestampRFC3339,
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		p, err := NewGenericRestProvider(ctx, zerolog.Nop(), endpoints, atomUsd)
+		require.NoError(t, err)
+		require.NoError(t, p.Poll())
+
+		prices, err := p.GetTickerPrices(atomUsd)
+		require.NoError(t, err)
+		ticker := prices["ATOMUSD"]
+		require.Equal(t, math.LegacyMustNewDecFromStr("10.5"), ticker.Price)
+		require.Equal(t, math.LegacyNewDec(20), ticker.Volume)
+		require.Equal(t, types.VolumeUnitQuote, ticker.VolumeUnit)
+		require.True(t, time.Unix(now.Unix(), 0).Equal(ticker.Time))
+
+		// the inverted symbol is not requested
+		mtx.Lock()
+		defer mtx.Unlock()
+		require.Equal(t, map[string]struct{}{"/ticker/ATOM-USD": {}}, paths)
+	})
+}
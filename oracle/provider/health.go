@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"price-feeder/oracle/types"
+)
+
+const (
+	healthRankInterval  = 30 * time.Second
+	healthAlpha         = 0.3
+	defaultMaxHeightLag = 10
+
+	heightCheckCosmos = "cosmos"
+	heightCheckEvm    = "evm"
+)
+
+type (
+	// endpointHealth scores the urls of a provider by their latency and
+	// error rate and keeps them ranked, best first. Urls serving heights
+	// behind the others are demoted below all healthy urls.
+	endpointHealth struct {
+		mtx          sync.RWMutex
+		scores       map[string]*urlScore
+		ranked       []string
+		heightCheck  string
+		maxHeightLag uint64
+	}
+
+	urlScore struct {
+		latency   time.Duration // moving average
+		errorRate float64       // moving average of failed requests
+		measured  bool
+		height    uint64
+		lagging   bool
+	}
+)
+
+func newEndpointHealth(urls []string, maxHeightLag uint64) *endpointHealth {
+	if maxHeightLag == 0 {
+		maxHeightLag = defaultMaxHeightLag
+	}
+
+	h := &endpointHealth{
+		scores:       make(map[string]*urlScore, len(urls)),
+		ranked:       make([]string, len(urls)),
+		maxHeightLag: maxHeightLag,
+	}
+	copy(h.ranked, urls)
+	for _, url := range urls {
+		h.scores[url] = &urlScore{}
+	}
+	return h
+}
+
+// score is the expected cost of a request in seconds, unmeasured urls
+// score 0 so that they are tried.
+func (s *urlScore) score() float64 {
+	score := s.latency.Seconds() + s.errorRate*defaultTimeout.Seconds()
+	if s.lagging {
+		score += 2 * defaultTimeout.Seconds()
+	}
+	return score
+}
+
+// urls returns the urls, best first.
+func (h *endpointHealth) urls() []string {
+	if h == nil {
+		return nil
+	}
+
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	urls := make([]string, len(h.ranked))
+	copy(urls, h.ranked)
+	return urls
+}
+
+// record updates the score of a url with the outcome of a request, urls
+// are re-ranked after failures so that the next request avoids them.
+func (h *endpointHealth) record(url string, latency time.Duration, err error) {
+	if h == nil {
+		return
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	score, found := h.scores[url]
+	if !found {
+		return
+	}
+
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	}
+
+	if !score.measured {
+		score.errorRate = failed
+		if err == nil {
+			score.latency = latency
+		}
+		score.measured = true
+	} else {
+		score.errorRate = healthAlpha*failed + (1-healthAlpha)*score.errorRate
+		if err == nil {
+			score.latency = time.Duration(
+				healthAlpha*float64(latency) + (1-healthAlpha)*float64(score.latency),
+			)
+		}
+	}
+
+	if err != nil {
+		h.rank()
+	}
+}
+
+// rank sorts the urls by score, must be called with the lock held.
+func (h *endpointHealth) rank() {
+	sort.SliceStable(h.ranked, func(i, j int) bool {
+		return h.scores[h.ranked[i]].score() < h.scores[h.ranked[j]].score()
+	})
+}
+
+// setHeightCheck enables the lagging height check of the given kind.
+func (h *endpointHealth) setHeightCheck(kind string) {
+	if h == nil {
+		return
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h.heightCheck = kind
+}
+
+// setHeights marks urls more than maxHeightLag blocks behind the highest
+// url as lagging.
+func (h *endpointHealth) setHeights(heights map[string]uint64) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	var max uint64
+	for _, height := range heights {
+		if height > max {
+			max = height
+		}
+	}
+
+	for url, score := range h.scores {
+		height, found := heights[url]
+		if !found {
+			continue
+		}
+		score.height = height
+		score.lagging = height+h.maxHeightLag < max
+	}
+}
+
+// runHealthChecks periodically checks the heights of all urls, if the
+// provider queries heights, and re-ranks them.
+func (p *provider) runHealthChecks(ctx context.Context) {
+	ticker := time.NewTicker(healthRankInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		p.health.mtx.RLock()
+		kind := p.health.heightCheck
+		p.health.mtx.RUnlock()
+
+		if kind != "" {
+			p.checkHeights(kind)
+		}
+
+		p.health.mtx.Lock()
+		p.health.rank()
+		p.health.mtx.Unlock()
+
+		for url, score := range p.health.snapshot() {
+			telemetryEndpointHealth(p.endpoints.Name, url, score)
+		}
+	}
+}
+
+// snapshot returns a copy of the scores.
+func (h *endpointHealth) snapshot() map[string]urlScore {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	scores := make(map[string]urlScore, len(h.scores))
+	for url, score := range h.scores {
+		scores[url] = *score
+	}
+	return scores
+}
+
+// checkHeights queries the latest height of every url.
+func (p *provider) checkHeights(kind string) {
+	heights := map[string]uint64{}
+	for _, url := range p.endpoints.Urls {
+		start := time.Now()
+		height, err := p.heightAt(url, kind)
+		p.health.record(url, time.Since(start), err)
+		if err != nil {
+			p.logger.Debug().Err(err).Str("url", url).Msg("failed to check height")
+			continue
+		}
+		heights[url] = height
+	}
+
+	p.health.setHeights(heights)
+
+	for url, score := range p.health.snapshot() {
+		if score.lagging {
+			p.logger.Warn().
+				Str("url", url).
+				Uint64("height", score.height).
+				Msg("endpoint is lagging behind")
+		}
+	}
+}
+
+// heightAt returns the latest height served by url.
+func (p *provider) heightAt(url, kind string) (uint64, error) {
+	switch kind {
+	case heightCheckCosmos:
+		content, err := p.makeHttpRequest(
+			url+"/cosmos/base/tendermint/v1beta1/blocks/latest", "GET", nil, nil,
+		)
+		if err != nil {
+			return 0, err
+		}
+		var response types.CosmosBlockResponse
+		err = json.Unmarshal(content, &response)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseUint(response.Block.Header.Height, 10, 64)
+	case heightCheckEvm:
+		content, err := p.makeHttpRequest(
+			url,
+			"POST",
+			[]byte(`{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`),
+			map[string]string{"Content-Type": "application/json"},
+		)
+		if err != nil {
+			return 0, err
+		}
+		var response struct {
+			Result string `json:"result"`
+		}
+		err = json.Unmarshal(content, &response)
+		if err != nil {
+			return 0, err
+		}
+		if len(response.Result) < 3 {
+			return 0, fmt.Errorf("invalid block number: %s", response.Result)
+		}
+		return strconv.ParseUint(response.Result[2:], 16, 64)
+	}
+	return 0, fmt.Errorf("unknown height check: %s", kind)
+}
+
+// hedgedRequest sends the request to the first url and, if it has not
+// answered after the hedge delay or failed, to the second one. The first
+// successful response wins, the other request is only used for scoring.
+func (p *provider) hedgedRequest(
+	urls [2]string,
+	path string,
+	method string,
+	body []byte,
+	headers map[string]string,
+) ([]byte, string, error) {
+	type result struct {
+		content []byte
+		url     string
+		err     error
+	}
+
+	results := make(chan result, len(urls))
+	send := func(url string) {
+		go func() {
+			start := time.Now()
+			content, err := p.makeHttpRequest(url+path, method, body, headers)
+			p.health.record(url, time.Since(start), err)
+			results <- result{content, url, err}
+		}()
+	}
+
+	send(urls[0])
+	sent, pending := 1, 1
+
+	timer := time.NewTimer(p.endpoints.Hedge)
+	defer timer.Stop()
+
+	var err error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				return res.content, res.url, nil
+			}
+			err = res.err
+		}
+		if sent < len(urls) {
+			send(urls[sent])
+			sent++
+			pending++
+		}
+	}
+	return nil, "", err
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointHealth_Rank(t *testing.T) {
+	h := newEndpointHealth([]string{"a", "b", "c"}, 0)
+
+	h.record("a", 300*time.Millisecond, nil)
+	h.record("b", 100*time.Millisecond, nil)
+	h.record("c", 200*time.Millisecond, nil)
+	h.mtx.Lock()
+	h.rank()
+	h.mtx.Unlock()
+	require.Equal(t, []string{"b", "c", "a"}, h.urls())
+
+	// failures re-rank immediately
+	h.record("b", 0, fmt.Errorf("timeout"))
+	require.Equal(t, []string{"c", "a", "b"}, h.urls())
+
+	// lagging urls rank below all others
+	h.setHeights(map[string]uint64{"a": 100, "b": 100, "c": 80})
+	h.mtx.Lock()
+	h.rank()
+	h.mtx.Unlock()
+	require.Equal(t, []string{"a", "b", "c"}, h.urls())
+}
+
+func TestProvider_HedgedRequest(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(time.Second)
+		fmt.Fprint(rw, "slow")
+	}))
+	defer slow.Close()
+
+	fast := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(rw, "fast")
+	}))
+	defer fast.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := &provider{}
+	p.Init(ctx, Endpoint{
+		Name:  "test",
+		Urls:  []string{slow.URL, fast.URL},
+		Hedge: 50 * time.Millisecond,
+	}, zerolog.Nop(), nil, nil, nil)
+
+	start := time.Now()
+	content, err := p.httpGet("/")
+	require.NoError(t, err)
+	require.Equal(t, "fast", string(content))
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+	require.Equal(t, fast.URL, p.httpBase)
+}
@@ -42,6 +42,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderHelix,
+			Defaults: helixDefaultEndpoints,
+			New:      withoutDb(NewHelixProvider),
+		},
+	)
+}
+
 func NewHelixProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -63,7 +73,7 @@ func NewHelixProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToHelixSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -35,6 +35,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderHitBtc,
+			Defaults: hitbtcDefaultEndpoints,
+			New:      withoutDb(NewHitBtcProvider),
+		},
+	)
+}
+
 func NewHitBtcProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -55,7 +65,7 @@ func NewHitBtcProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToHitBtcSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/gorilla/websocket"
+)
+
+type (
+	// HttpOptions customizes how a provider connects to its endpoints, for
+	// rest requests and websockets alike. Proxy is an http, https or socks5
+	// url. The ApiKey is sent in the ApiKeyHeader and/or the ApiKeyParam
+	// query parameter.
+	HttpOptions struct {
+		Proxy        string
+		Headers      map[string]string
+		ApiKey       string
+		ApiKeyHeader string
+		ApiKeyParam  string
+		TLS          *TLSOptions
+	}
+
+	// TLSOptions defines a custom CA, a client certificate and the server
+	// name to verify, ex. for internal endpoints.
+	TLSOptions struct {
+		CAFile             string
+		CertFile           string
+		KeyFile            string
+		ServerName         string
+		InsecureSkipVerify bool
+	}
+)
+
+// Validate returns an error if the proxy or the tls settings are invalid.
+func (o *HttpOptions) Validate() error {
+	if _, err := o.proxy(); err != nil {
+		return err
+	}
+	_, err := o.TLSConfig()
+	return err
+}
+
+// TLSConfig returns the tls config of the options, nil if unset.
+func (o *HttpOptions) TLSConfig() (*tls.Config, error) {
+	if o == nil || o.TLS == nil {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		ServerName:         o.TLS.ServerName,
+		InsecureSkipVerify: o.TLS.InsecureSkipVerify, //nolint:gosec
+	}
+
+	if o.TLS.CAFile != "" {
+		pem, err := os.ReadFile(o.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", o.TLS.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if o.TLS.CertFile != "" || o.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.TLS.CertFile, o.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// proxy returns the proxy function of the options, from the environment
+// if unset.
+func (o *HttpOptions) proxy() (func(*http.Request) (*url.URL, error), error) {
+	if o == nil || o.Proxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyUrl, err := url.Parse(o.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy: %w", err)
+	}
+	switch proxyUrl.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme: %s", proxyUrl.Scheme)
+	}
+	return http.ProxyURL(proxyUrl), nil
+}
+
+// newHTTPClient returns a client using the proxy and tls settings.
+func newHTTPClient(options *HttpOptions) (*http.Client, error) {
+	client := newDefaultHTTPClient()
+	if options == nil {
+		return client, nil
+	}
+
+	proxy, err := options.proxy()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := options.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	transport.TLSClientConfig = tlsConfig
+	client.Transport = transport
+
+	return client, nil
+}
+
+// websocketDialer returns a dialer using the proxy and tls settings, and
+// the headers to send when dialing.
+func (o *HttpOptions) websocketDialer() (*websocket.Dialer, http.Header, error) {
+	proxy, err := o.proxy()
+	if err != nil {
+		return nil, nil, err
+	}
+	tlsConfig, err := o.TLSConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dialer := *websocket.DefaultDialer
+	dialer.Proxy = proxy
+	dialer.TLSClientConfig = tlsConfig
+
+	header := http.Header{}
+	o.setHeaders(header)
+
+	return &dialer, header, nil
+}
+
+// setHeaders adds the custom headers and the api key header.
+func (o *HttpOptions) setHeaders(header http.Header) {
+	if o == nil {
+		return
+	}
+	for key, value := range o.Headers {
+		header.Set(key, value)
+	}
+	if o.ApiKey != "" && o.ApiKeyHeader != "" {
+		header.Set(o.ApiKeyHeader, o.ApiKey)
+	}
+}
+
+// setQuery adds the api key query parameter.
+func (o *HttpOptions) setQuery(u *url.URL) {
+	if o == nil || o.ApiKey == "" || o.ApiKeyParam == "" {
+		return
+	}
+	query := u.Query()
+	query.Set(o.ApiKeyParam, o.ApiKey)
+	u.RawQuery = query.Encode()
+}
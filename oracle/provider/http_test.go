@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpOptions_Request(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(rw, "%s %s %s",
+			req.Header.Get("X-Custom"),
+			req.Header.Get("X-Api-Key"),
+			req.URL.Query().Get("apikey"),
+		)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := &provider{}
+	p.Init(ctx, Endpoint{
+		Name: "test",
+		Urls: []string{server.URL},
+		Http: &HttpOptions{
+			Headers:      map[string]string{"X-Custom": "custom"},
+			ApiKey:       "secret",
+			ApiKeyHeader: "X-Api-Key",
+			ApiKeyParam:  "apikey",
+		},
+	}, zerolog.Nop(), nil, nil, nil)
+
+	content, err := p.httpGet("/ticker?symbol=ATOMUSDT")
+	require.NoError(t, err)
+	require.Equal(t, "custom secret secret", string(content))
+}
+
+func TestHttpOptions_Proxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// proxies receive the absolute url of the target
+		fmt.Fprint(rw, req.URL.String())
+	}))
+	defer proxy.Close()
+
+	client, err := newHTTPClient(&HttpOptions{Proxy: proxy.URL})
+	require.NoError(t, err)
+
+	res, err := client.Get("http://provider.invalid/ticker")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	_, err = newHTTPClient(&HttpOptions{Proxy: "ftp://proxy"})
+	require.Error(t, err)
+
+	err = (&HttpOptions{TLS: &TLSOptions{CAFile: "missing.pem"}}).Validate()
+	require.Error(t, err)
+}
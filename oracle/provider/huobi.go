@@ -31,6 +31,7 @@ type (
 
 	HuobiTickersResponse struct {
 		Data []HuobiTicker `json:"data"`
+		Time int64         `json:"ts"` // ex.: 1630982370526
 	}
 
 	HuobiTicker struct {
@@ -40,6 +41,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderHuobi,
+			Defaults: huobiDefaultEndpoints,
+			New:      withoutDb(NewHuobiProvider),
+		},
+	)
+}
+
 func NewHuobiProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -59,7 +70,7 @@ func NewHuobiProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToHuobiSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -86,7 +97,7 @@ func (p *HuobiProvider) Poll() error {
 
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
-	now := time.Now()
+	timestamp := unixMilliOrNow(tickers.Time)
 
 	for _, ticker := range tickers.Data {
 		if !p.isPair(ticker.Symbol) {
@@ -97,7 +108,7 @@ func (p *HuobiProvider) Poll() error {
 			ticker.Symbol,
 			floatToDec(ticker.Price),
 			floatToDec(ticker.Volume),
-			now,
+			timestamp,
 		)
 	}
 	p.logger.Debug().Msg("updated tickers")
@@ -42,6 +42,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderIdxOsmosis,
+			Defaults: idxOsmosisDefaultEndpoints,
+			New:      withoutDb(NewIdxProvider),
+		},
+	)
+}
+
 func NewIdxProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -69,7 +79,7 @@ func NewIdxProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToIdxSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -3,10 +3,12 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"price-feeder/oracle/types"
 
+	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
 )
 
@@ -16,6 +18,10 @@ var (
 		Name:         ProviderKraken,
 		Urls:         []string{"https://api.kraken.com"},
 		PollInterval: 2 * time.Second,
+		Websocket:    "ws.kraken.com",
+		PingDuration: defaultPingDuration,
+		PingType:     websocket.TextMessage,
+		PingMessage:  `{"event":"ping"}`,
 	}
 )
 
@@ -24,8 +30,11 @@ type (
 	// public API.
 	//
 	// REF: https://docs.kraken.com/rest
+	// REF: https://docs.kraken.com/websockets
 	KrakenProvider struct {
 		provider
+		wsNames map[string]string // ex.: "XXBTZUSD": "XBT/USD"
+		symbols map[string]string // ex.: "XBT/USD": "XXBTZUSD"
 	}
 
 	KrakenTickerResponse struct {
@@ -44,8 +53,45 @@ type (
 	KrakenPair struct {
 		WsName string `json:"wsname"` // ex.: "XBT/USD"
 	}
+
+	// KrakenTradesResponse contains the trades by symbol and the "last"
+	// cursor, ex.: {"XXBTZUSD": [["30243.40000", "0.34507674",
+	// 1688669597.827, "b", "m", "", 61044952]], "last": "1688671969993150842"}
+	KrakenTradesResponse struct {
+		Result map[string]json.RawMessage `json:"result"`
+	}
+
+	KrakenOrderBookResponse struct {
+		Result map[string]KrakenOrderBook `json:"result"`
+	}
+
+	KrakenOrderBook struct {
+		Bids [][]json.RawMessage `json:"bids"` // ex.: [["0.52900", "94.235", 1688671834]]
+		Asks [][]json.RawMessage `json:"asks"` // ex.: [["0.53000", "12.500", 1688671834]]
+	}
+
+	KrakenSubscriptionMsg struct {
+		Event        string                    `json:"event"` // subscribe
+		Pair         []string                  `json:"pair"`  // ex.: ["XBT/USD"]
+		Subscription KrakenSubscriptionChannel `json:"subscription"`
+	}
+
+	KrakenSubscriptionChannel struct {
+		Name string `json:"name"` // ticker
+	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:      ProviderKraken,
+			Defaults:  krakenDefaultEndpoints,
+			New:       withoutDb(NewKrakenProvider),
+			Websocket: true,
+		},
+	)
+}
+
 func NewKrakenProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -58,14 +104,23 @@ func NewKrakenProvider(
 		endpoints,
 		logger,
 		pairs,
-		nil,
-		nil,
+		provider.messageReceived,
+		provider.getSubscriptionMsgs,
 	)
+	provider.setOrderBookHandler(provider.getOrderBook)
+	provider.setTradeHandler(provider.getTrades, false)
 
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToKrakenSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	err := provider.setWsNames()
+	if err != nil {
+		provider.logger.Warn().Err(err).Msg("failed to get websocket pair names")
+	} else {
+		provider.startWebsocket()
+	}
+
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -111,6 +166,169 @@ func (p *KrakenProvider) Poll() error {
 	return nil
 }
 
+// setWsNames fetches the websocket names of all asset pairs, which differ
+// from the symbols used by the REST API.
+func (p *KrakenProvider) setWsNames() error {
+	content, err := p.httpGet("/0/public/AssetPairs")
+	if err != nil {
+		return err
+	}
+
+	var response KrakenPairsResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return err
+	}
+
+	p.wsNames = map[string]string{}
+	p.symbols = map[string]string{}
+	for symbol, pair := range response.Result {
+		p.wsNames[symbol] = pair.WsName
+		p.symbols[pair.WsName] = symbol
+	}
+
+	return nil
+}
+
+func (p *KrakenProvider) getOrderBook(symbol string, depth int) (OrderBook, error) {
+	path := fmt.Sprintf("/0/public/Depth?pair=%s&count=%d", symbol, depth)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	var response KrakenOrderBookResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	for _, book := range response.Result {
+		return NewOrderBook(book.Bids, book.Asks)
+	}
+
+	return OrderBook{}, p.errorf("order book not found")
+}
+
+func (p *KrakenProvider) getTrades(symbol string) ([]Trade, error) {
+	content, err := p.httpGet("/0/public/Trades?pair=" + symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var response KrakenTradesResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, raw := range response.Result {
+		if key == "last" {
+			continue
+		}
+
+		var levels [][]json.RawMessage
+		err = json.Unmarshal(raw, &levels)
+		if err != nil {
+			return nil, err
+		}
+
+		trades := []Trade{}
+		for _, level := range levels {
+			if len(level) < 3 {
+				return nil, p.errorf("invalid trade")
+			}
+
+			price, err := rawToDec(level[0])
+			if err != nil {
+				return nil, err
+			}
+
+			amount, err := rawToDec(level[1])
+			if err != nil {
+				return nil, err
+			}
+
+			seconds, err := rawToDec(level[2])
+			if err != nil {
+				return nil, err
+			}
+
+			trade := Trade{
+				Price:  price,
+				Amount: amount,
+				Time:   time.UnixMilli(seconds.MulInt64(1000).TruncateInt64()),
+			}
+			if len(level) > 6 {
+				trade.ID = string(level[6])
+			}
+
+			trades = append(trades, trade)
+		}
+
+		return trades, nil
+	}
+
+	return nil, p.errorf("trades not found")
+}
+
+func (p *KrakenProvider) messageReceived(messageType int, bz []byte) {
+	// ticker messages are arrays, ex.: [340, {"c": [...], ...}, "ticker", "XBT/USD"]
+	var message []json.RawMessage
+	err := json.Unmarshal(bz, &message)
+	if err != nil || len(message) != 4 {
+		return
+	}
+
+	var channel, wsName string
+	if json.Unmarshal(message[2], &channel) != nil || channel != "ticker" {
+		return
+	}
+	if json.Unmarshal(message[3], &wsName) != nil {
+		return
+	}
+
+	var ticker KrakenTicker
+	err = json.Unmarshal(message[1], &ticker)
+	if err != nil {
+		p.logger.Debug().Err(err).Msg("failed to unmarshal ticker")
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	symbol, found := p.symbols[wsName]
+	if !found || !p.isPair(symbol) {
+		return
+	}
+
+	p.setTickerPrice(
+		symbol,
+		strToDec(ticker.Price[0]),
+		strToDec(ticker.Volume[1]),
+		time.Now(),
+	)
+}
+
+func (p *KrakenProvider) getSubscriptionMsgs(pairs ...types.CurrencyPair) []interface{} {
+	wsNames := []string{}
+	for _, symbol := range p.websocketSymbols(pairs...) {
+		wsName, found := p.wsNames[symbol]
+		if found {
+			wsNames = append(wsNames, wsName)
+		}
+	}
+
+	return []interface{}{
+		KrakenSubscriptionMsg{
+			Event:        "subscribe",
+			Pair:         wsNames,
+			Subscription: KrakenSubscriptionChannel{Name: "ticker"},
+		},
+	}
+}
+
 func (p *KrakenProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	tickers, err := p.getTickers()
 	if err != nil {
@@ -3,10 +3,15 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"price-feeder/oracle/types"
 
+	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
 )
 
@@ -16,6 +21,10 @@ var (
 		Name:         ProviderKucoin,
 		Urls:         []string{"https://api.kucoin.com"},
 		PollInterval: 2 * time.Second,
+		Websocket:    "ws-api-spot.kucoin.com",
+		PingDuration: defaultPingDuration,
+		PingType:     websocket.TextMessage,
+		PingMessage:  `{"id":"ping","type":"ping"}`,
 	}
 )
 
@@ -35,6 +44,7 @@ type (
 
 	KucoinTickersResponseData struct {
 		Ticker []KucoinTicker `json:"ticker"`
+		Time   int64          `json:"time"` // ex.: 1602832092060
 	}
 
 	KucoinTicker struct {
@@ -42,8 +52,75 @@ type (
 		Price  string `json:"last"`   // Last price ex.: 0.0025
 		Volume string `json:"vol"`    // Total traded base asset volume ex.: 1000
 	}
+
+	KucoinBulletResponse struct {
+		Data KucoinBulletData `json:"data"`
+	}
+
+	KucoinBulletData struct {
+		Token           string                 `json:"token"`
+		InstanceServers []KucoinInstanceServer `json:"instanceServers"`
+	}
+
+	KucoinInstanceServer struct {
+		Endpoint string `json:"endpoint"` // ex.: wss://ws-api-spot.kucoin.com/
+	}
+
+	KucoinWsSnapshotResponse struct {
+		Type    string               `json:"type"`    // ex.: message
+		Subject string               `json:"subject"` // ex.: trade.snapshot
+		Data    KucoinWsSnapshotData `json:"data"`
+	}
+
+	KucoinWsSnapshotData struct {
+		Data KucoinWsSnapshot `json:"data"`
+	}
+
+	KucoinWsSnapshot struct {
+		Symbol string      `json:"symbol"`          // ex.: BTC-USDT
+		Price  json.Number `json:"lastTradedPrice"` // ex.: 0.0025
+		Volume json.Number `json:"vol"`             // ex.: 1000
+	}
+
+	KucoinTradesResponse struct {
+		Data []KucoinTrade `json:"data"`
+	}
+
+	KucoinTrade struct {
+		Sequence string `json:"sequence"` // ex.: "1545896668571"
+		Price    string `json:"price"`    // ex.: "0.07"
+		Size     string `json:"size"`     // ex.: "0.004"
+		Time     int64  `json:"time"`     // nanoseconds ex.: 1545904567062140823
+	}
+
+	KucoinOrderBookResponse struct {
+		Data KucoinOrderBook `json:"data"`
+	}
+
+	KucoinOrderBook struct {
+		Bids [][]json.RawMessage `json:"bids"` // ex.: [["6500.12", "0.45054140"]]
+		Asks [][]json.RawMessage `json:"asks"` // ex.: [["6500.16", "0.57753524"]]
+	}
+
+	KucoinSubscriptionMsg struct {
+		ID       string `json:"id"`
+		Type     string `json:"type"`  // subscribe
+		Topic    string `json:"topic"` // ex.: /market/snapshot:BTC-USDT,ETH-USDT
+		Response bool   `json:"response"`
+	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:      ProviderKucoin,
+			Defaults:  kucoinDefaultEndpoints,
+			New:       withoutDb(NewKucoinProvider),
+			Websocket: true,
+		},
+	)
+}
+
 func NewKucoinProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -56,14 +133,21 @@ func NewKucoinProvider(
 		endpoints,
 		logger,
 		pairs,
-		nil,
-		nil,
+		provider.messageReceived,
+		provider.getSubscriptionMsgs,
 	)
+	provider.setOrderBookHandler(provider.getOrderBook)
+	provider.setTradeHandler(provider.getTrades, false)
 
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToKucoinSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	if provider.websocket != nil {
+		provider.websocket.SetURLHandler(provider.getWebsocketURL)
+		provider.startWebsocket()
+	}
+
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -90,7 +174,7 @@ func (p *KucoinProvider) Poll() error {
 
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
-	now := time.Now()
+	timestamp := unixMilliOrNow(tickers.Data.Time)
 
 	for _, ticker := range tickers.Data.Ticker {
 		if !p.isPair(ticker.Symbol) {
@@ -101,13 +185,135 @@ func (p *KucoinProvider) Poll() error {
 			ticker.Symbol,
 			strToDec(ticker.Price),
 			strToDec(ticker.Volume),
-			now,
+			timestamp,
 		)
 	}
 	p.logger.Debug().Msg("updated tickers")
 	return nil
 }
 
+// getWebsocketURL requests a token for the public websocket, which is
+// required for every new connection.
+func (p *KucoinProvider) getWebsocketURL() (url.URL, error) {
+	content, err := p.httpPost("/api/v1/bullet-public", nil)
+	if err != nil {
+		return url.URL{}, err
+	}
+
+	var response KucoinBulletResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return url.URL{}, err
+	}
+
+	if len(response.Data.InstanceServers) == 0 {
+		return url.URL{}, fmt.Errorf("no instance servers found")
+	}
+
+	websocketURL, err := url.Parse(response.Data.InstanceServers[0].Endpoint)
+	if err != nil {
+		return url.URL{}, err
+	}
+
+	query := websocketURL.Query()
+	query.Set("token", response.Data.Token)
+	websocketURL.RawQuery = query.Encode()
+
+	return *websocketURL, nil
+}
+
+func (p *KucoinProvider) getOrderBook(symbol string, depth int) (OrderBook, error) {
+	// only aggregated books of 20 or 100 levels are public
+	level := 20
+	if depth > 20 {
+		level = 100
+	}
+
+	path := fmt.Sprintf("/api/v1/market/orderbook/level2_%d?symbol=%s", level, symbol)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	var response KucoinOrderBookResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	return NewOrderBook(response.Data.Bids, response.Data.Asks)
+}
+
+func (p *KucoinProvider) getTrades(symbol string) ([]Trade, error) {
+	content, err := p.httpGet("/api/v1/market/histories?symbol=" + symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var response KucoinTradesResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]Trade, len(response.Data))
+	for i, trade := range response.Data {
+		trades[i] = Trade{
+			ID:     trade.Sequence,
+			Price:  strToDec(trade.Price),
+			Amount: strToDec(trade.Size),
+			Time:   time.Unix(0, trade.Time),
+		}
+	}
+
+	return trades, nil
+}
+
+func (p *KucoinProvider) messageReceived(messageType int, bz []byte) {
+	var response KucoinWsSnapshotResponse
+	err := json.Unmarshal(bz, &response)
+	if err != nil || response.Subject != "trade.snapshot" {
+		return
+	}
+
+	ticker := response.Data.Data
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !p.isPair(ticker.Symbol) {
+		return
+	}
+
+	p.setTickerPrice(
+		ticker.Symbol,
+		strToDec(ticker.Price.String()),
+		strToDec(ticker.Volume.String()),
+		time.Now(),
+	)
+}
+
+func (p *KucoinProvider) getSubscriptionMsgs(pairs ...types.CurrencyPair) []interface{} {
+	// topics are limited to 100 symbols per request
+	msgs := []interface{}{}
+	symbols := p.websocketSymbols(pairs...)
+	for i := 0; i < len(symbols); i += 100 {
+		end := i + 100
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+
+		msgs = append(msgs, KucoinSubscriptionMsg{
+			ID:       strconv.Itoa(i),
+			Type:     "subscribe",
+			Topic:    "/market/snapshot:" + strings.Join(symbols[i:end], ","),
+			Response: true,
+		})
+	}
+
+	return msgs
+}
+
 func (p *KucoinProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	tickers, err := p.getTickers()
 	if err != nil {
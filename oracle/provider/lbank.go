@@ -45,6 +45,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderLbank,
+			Defaults: lbankDefaultEndpoints,
+			New:      withoutDb(NewLbankProvider),
+		},
+	)
+}
+
 func NewLbankProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -64,7 +74,7 @@ func NewLbankProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToLbankSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
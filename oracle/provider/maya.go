@@ -36,6 +36,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderMaya,
+			Defaults: mayaDefaultEndpoints,
+			New:      withoutDb(NewMayaProvider),
+		},
+	)
+}
+
 func NewMayaProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -57,7 +67,7 @@ func NewMayaProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, nil)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -100,13 +110,15 @@ func (p *MayaProvider) Poll() error {
 			continue
 		}
 
+		// volume24h is reported in the pool's quote asset (cacao)
 		price := strToDec(pool.Price)
-		volume := strToDec(pool.Volume).Quo(precision).Quo(price)
+		volume := strToDec(pool.Volume).Quo(precision)
 
-		p.setTickerPrice(
+		p.setTickerPriceWithUnit(
 			symbol,
 			price,
 			volume,
+			types.VolumeUnitQuote,
 			timestamp,
 		)
 	}
@@ -32,9 +32,20 @@ type (
 		Symbol string `json:"symbol"`    // Symbol ex.: BTC-USDT
 		Price  string `json:"lastPrice"` // Last price ex.: 0.0025
 		Volume string `json:"volume"`    // Total traded base asset volume ex.: 1000
+		Time   int64  `json:"closeTime"` // Statistics close time ex.: 1499869899040
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderMexc,
+			Defaults: mexcDefaultEndpoints,
+			New:      withoutDb(NewMexcProvider),
+		},
+	)
+}
+
 func NewMexcProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -54,7 +65,7 @@ func NewMexcProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToMexcSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -81,7 +92,6 @@ func (p *MexcProvider) Poll() error {
 
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
-	now := time.Now()
 	for _, ticker := range tickers {
 		if !p.isPair(ticker.Symbol) {
 			continue
@@ -91,7 +101,7 @@ func (p *MexcProvider) Poll() error {
 			ticker.Symbol,
 			strToDec(ticker.Price),
 			strToDec(ticker.Volume),
-			now,
+			unixMilliOrNow(ticker.Time),
 		)
 	}
 	p.logger.Debug().Msg("updated tickers")
@@ -37,6 +37,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderMock,
+			Defaults: mockDefaultEndpoints,
+			New:      withoutDb(NewMockProvider),
+		},
+	)
+}
+
 func NewMockProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
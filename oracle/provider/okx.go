@@ -3,20 +3,27 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"time"
 
 	"price-feeder/oracle/types"
 
+	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
 )
 
 var (
 	_                   Provider = (*OkxProvider)(nil)
 	okxDefaultEndpoints          = Endpoint{
-		Name:         ProviderOkx,
-		Urls:         []string{"https://www.okx.com", "https://aws.okx.com"},
-		PollInterval: 2 * time.Second,
+		Name:          ProviderOkx,
+		Urls:          []string{"https://www.okx.com", "https://aws.okx.com"},
+		PollInterval:  2 * time.Second,
+		Websocket:     "ws.okx.com:8443",
+		WebsocketPath: "/ws/v5/public",
+		PingDuration:  defaultPingDuration,
+		PingType:      websocket.TextMessage,
+		PingMessage:   "ping",
 	}
 )
 
@@ -41,8 +48,56 @@ type (
 		Volume string `json:"vol24h"` // Total traded base asset volume ex.: 1000
 		Time   string `json:"ts"`     // Timestamp ex.: 1675246930699
 	}
+
+	OkxWsTickersResponse struct {
+		Arg  OkxSubscriptionArg `json:"arg"`
+		Data []OkxTicker        `json:"data"`
+	}
+
+	OkxTradesResponse struct {
+		Arg  OkxSubscriptionArg `json:"arg"`
+		Data []OkxTrade         `json:"data"`
+	}
+
+	OkxTrade struct {
+		Symbol string `json:"instId"`  // ex.: BTC-USDT
+		ID     string `json:"tradeId"` // ex.: 242720720
+		Price  string `json:"px"`      // ex.: 29963.2
+		Size   string `json:"sz"`      // ex.: 0.00001
+		Time   string `json:"ts"`      // ex.: 1654161646974
+	}
+
+	OkxOrderBookResponse struct {
+		Data []OkxOrderBook `json:"data"`
+	}
+
+	OkxOrderBook struct {
+		Bids [][]json.RawMessage `json:"bids"` // ex.: [["41006.8", "0.6", "0", "1"]]
+		Asks [][]json.RawMessage `json:"asks"` // ex.: [["41006.9", "0.1", "0", "2"]]
+	}
+
+	OkxSubscriptionMsg struct {
+		Op   string               `json:"op"` // subscribe
+		Args []OkxSubscriptionArg `json:"args"`
+	}
+
+	OkxSubscriptionArg struct {
+		Channel string `json:"channel"` // tickers
+		Symbol  string `json:"instId"`  // ex.: BTC-USDT
+	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:      ProviderOkx,
+			Defaults:  okxDefaultEndpoints,
+			New:       withoutDb(NewOkxProvider),
+			Websocket: true,
+		},
+	)
+}
+
 func NewOkxProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -55,14 +110,17 @@ func NewOkxProvider(
 		endpoints,
 		logger,
 		pairs,
-		nil,
-		nil,
+		provider.messageReceived,
+		provider.getSubscriptionMsgs,
 	)
+	provider.setOrderBookHandler(provider.getOrderBook)
+	provider.setTradeHandler(provider.getTrades, true)
 
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToOkxSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startWebsocket()
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -113,6 +171,148 @@ func (p *OkxProvider) Poll() error {
 	return nil
 }
 
+func (p *OkxProvider) getOrderBook(symbol string, depth int) (OrderBook, error) {
+	path := fmt.Sprintf("/api/v5/market/books?instId=%s&sz=%d", symbol, depth)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	var response OkxOrderBookResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	if len(response.Data) == 0 {
+		return OrderBook{}, p.errorf("order book not found")
+	}
+
+	return NewOrderBook(response.Data[0].Bids, response.Data[0].Asks)
+}
+
+func (p *OkxProvider) getTrades(symbol string) ([]Trade, error) {
+	path := fmt.Sprintf("/api/v5/market/trades?instId=%s&limit=500", symbol)
+	content, err := p.httpGet(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var response OkxTradesResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return okxTrades(response.Data)
+}
+
+func okxTrades(okxTrades []OkxTrade) ([]Trade, error) {
+	trades := []Trade{}
+	for _, trade := range okxTrades {
+		timestamp, err := strconv.ParseInt(trade.Time, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		trades = append(trades, Trade{
+			ID:     trade.ID,
+			Price:  strToDec(trade.Price),
+			Amount: strToDec(trade.Size),
+			Time:   time.UnixMilli(timestamp),
+		})
+	}
+	return trades, nil
+}
+
+func (p *OkxProvider) messageReceived(messageType int, bz []byte) {
+	var response OkxWsTickersResponse
+	err := json.Unmarshal(bz, &response)
+
+	// trade messages share the envelope of ticker messages
+	if err == nil && response.Arg.Channel == "trades" {
+		p.tradeReceived(bz)
+		return
+	}
+
+	if err != nil || response.Arg.Channel != "tickers" {
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, ticker := range response.Data {
+		if !p.isPair(ticker.Symbol) {
+			continue
+		}
+
+		timestamp, err := strconv.ParseInt(ticker.Time, 0, 64)
+		if err != nil {
+			p.logger.
+				Err(err).
+				Msg("failed parsing timestamp")
+			continue
+		}
+
+		p.setTickerPrice(
+			ticker.Symbol,
+			strToDec(ticker.Price),
+			strToDec(ticker.Volume),
+			time.UnixMilli(timestamp),
+		)
+	}
+}
+
+func (p *OkxProvider) tradeReceived(bz []byte) {
+	var response OkxTradesResponse
+	err := json.Unmarshal(bz, &response)
+	if err != nil {
+		p.logger.Debug().Err(err).Msg("failed to unmarshal trades")
+		return
+	}
+
+	telemetryWebsocketMessage(p.endpoints.Name, MessageTypeTrade)
+
+	trades, err := okxTrades(response.Data)
+	if err != nil {
+		p.logger.Err(err).Msg("failed parsing timestamp")
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	symbol := response.Arg.Symbol
+	if !p.isPair(symbol) {
+		return
+	}
+
+	p.setTrades(symbol, trades...)
+}
+
+func (p *OkxProvider) getSubscriptionMsgs(pairs ...types.CurrencyPair) []interface{} {
+	channel := "tickers"
+	if p.isTradeMode() {
+		channel = "trades"
+	}
+
+	args := []OkxSubscriptionArg{}
+	for _, symbol := range p.websocketSymbols(pairs...) {
+		args = append(args, OkxSubscriptionArg{
+			Channel: channel,
+			Symbol:  symbol,
+		})
+	}
+
+	return []interface{}{
+		OkxSubscriptionMsg{
+			Op:   "subscribe",
+			Args: args,
+		},
+	}
+}
+
 func (p *OkxProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	tickers, err := p.getTickers()
 	if err != nil {
@@ -0,0 +1,280 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"cosmossdk.io/math"
+)
+
+const defaultOrderBookDepth = 20
+
+type (
+	// OrderBookOptions enables the order book mode of a provider. Prices
+	// are the mid-price of the best bid and ask or, if Notional is set,
+	// the mid of the average prices to buy and sell the notional (in quote
+	// of the provider symbol). Ticks with a relative spread above the
+	// limit of their pair are rejected.
+	OrderBookOptions struct {
+		Depth      int
+		Notional   math.LegacyDec
+		MaxSpreads map[string]math.LegacyDec
+	}
+
+	OrderBook struct {
+		Bids []OrderBookLevel
+		Asks []OrderBookLevel
+	}
+
+	OrderBookLevel struct {
+		Price  math.LegacyDec
+		Amount math.LegacyDec
+	}
+
+	// OrderBookHandler returns the order book of the provider symbol.
+	OrderBookHandler func(symbol string, depth int) (OrderBook, error)
+
+	orderBookPrice struct {
+		price  math.LegacyDec
+		spread math.LegacyDec
+		time   time.Time
+	}
+)
+
+// NewOrderBook parses the bids and asks of exchanges that return levels as
+// arrays starting with price and amount, ex.: [["0.1", "100"], ...].
+func NewOrderBook(bids, asks [][]json.RawMessage) (OrderBook, error) {
+	var book OrderBook
+	var err error
+
+	book.Bids, err = newOrderBookLevels(bids)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	book.Asks, err = newOrderBookLevels(asks)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	sort.Slice(book.Bids, func(i, j int) bool {
+		return book.Bids[i].Price.GT(book.Bids[j].Price)
+	})
+	sort.Slice(book.Asks, func(i, j int) bool {
+		return book.Asks[i].Price.LT(book.Asks[j].Price)
+	})
+
+	return book, nil
+}
+
+func newOrderBookLevels(raw [][]json.RawMessage) ([]OrderBookLevel, error) {
+	levels := make([]OrderBookLevel, 0, len(raw))
+	for _, level := range raw {
+		if len(level) < 2 {
+			return nil, fmt.Errorf("invalid order book level")
+		}
+
+		price, err := rawToDec(level[0])
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := rawToDec(level[1])
+		if err != nil {
+			return nil, err
+		}
+
+		levels = append(levels, OrderBookLevel{Price: price, Amount: amount})
+	}
+	return levels, nil
+}
+
+// rawToDec parses a json string or number.
+func rawToDec(raw json.RawMessage) (math.LegacyDec, error) {
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return math.LegacyNewDecFromStr(str)
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(raw, &number); err != nil {
+		return math.LegacyDec{}, err
+	}
+	return math.LegacyNewDecFromStr(number.String())
+}
+
+// MidPrice returns the mid of the best bid and ask.
+func (b OrderBook) MidPrice() (math.LegacyDec, error) {
+	if len(b.Bids) == 0 || len(b.Asks) == 0 {
+		return math.LegacyDec{}, fmt.Errorf("empty order book")
+	}
+	return b.Bids[0].Price.Add(b.Asks[0].Price).QuoInt64(2), nil
+}
+
+// Spread returns the spread of the best bid and ask relative to the mid.
+func (b OrderBook) Spread() (math.LegacyDec, error) {
+	mid, err := b.MidPrice()
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+	if !mid.IsPositive() {
+		return math.LegacyDec{}, fmt.Errorf("invalid mid price")
+	}
+	return b.Asks[0].Price.Sub(b.Bids[0].Price).Quo(mid), nil
+}
+
+// DepthWeightedPrice returns the mid of the average prices to buy and sell
+// the notional in quote.
+func (b OrderBook) DepthWeightedPrice(notional math.LegacyDec) (math.LegacyDec, error) {
+	buy, err := averageFillPrice(b.Asks, notional)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	sell, err := averageFillPrice(b.Bids, notional)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	return buy.Add(sell).QuoInt64(2), nil
+}
+
+func averageFillPrice(levels []OrderBookLevel, notional math.LegacyDec) (math.LegacyDec, error) {
+	remaining := notional
+	filled := math.LegacyZeroDec()
+
+	for _, level := range levels {
+		value := level.Price.Mul(level.Amount)
+		if value.GTE(remaining) {
+			filled = filled.Add(remaining.Quo(level.Price))
+			return notional.Quo(filled), nil
+		}
+		remaining = remaining.Sub(value)
+		filled = filled.Add(level.Amount)
+	}
+
+	return math.LegacyDec{}, fmt.Errorf("insufficient order book depth")
+}
+
+func (p *provider) setOrderBookHandler(handler OrderBookHandler) {
+	p.orderBookHandler = handler
+}
+
+func (p *provider) isOrderBookMode() bool {
+	return p.endpoints.OrderBook != nil && p.orderBookHandler != nil
+}
+
+// pollOrderBooks fetches the order books of all pairs and stores the
+// resulting prices. Pairs without a valid order book price are not
+// returned by GetTickerPrices.
+func (p *provider) pollOrderBooks() {
+	if !p.isOrderBookMode() {
+		return
+	}
+
+	depth := p.endpoints.OrderBook.Depth
+	if depth <= 0 {
+		depth = defaultOrderBookDepth
+	}
+
+	p.mtx.RLock()
+	pairs := p.getAllPairs()
+	p.mtx.RUnlock()
+
+	for symbol := range pairs {
+		book, err := p.orderBookHandler(symbol, depth)
+		timestamp := time.Now()
+
+		if len(book.Bids) > depth {
+			book.Bids = book.Bids[:depth]
+		}
+		if len(book.Asks) > depth {
+			book.Asks = book.Asks[:depth]
+		}
+
+		p.locked(func() {
+			if err != nil {
+				p.logger.Warn().Err(err).Str("symbol", symbol).Msg("failed to get order book")
+				p.removeOrderBookPrice(symbol)
+			} else {
+				p.setOrderBookPrice(symbol, book, timestamp)
+			}
+		})
+	}
+}
+
+func (p *provider) setOrderBookPrice(symbol string, book OrderBook, timestamp time.Time) {
+	pair, found := p.getPair(symbol)
+	if !found {
+		return
+	}
+
+	spread, err := book.Spread()
+	if err != nil {
+		p.logger.Warn().Err(err).Str("symbol", symbol).Msg("invalid order book")
+		p.removeOrderBookPrice(symbol)
+		return
+	}
+
+	var price math.LegacyDec
+	notional := p.endpoints.OrderBook.Notional
+	if notional.IsNil() || notional.IsZero() {
+		price, err = book.MidPrice()
+	} else {
+		price, err = book.DepthWeightedPrice(notional)
+	}
+	if err != nil {
+		p.logger.Warn().Err(err).Str("symbol", symbol).Msg("invalid order book")
+		p.removeOrderBookPrice(symbol)
+		return
+	}
+
+	_, inverse := p.inverse[symbol]
+	if inverse {
+		pair = pair.Swap()
+		price = math.LegacyOneDec().Quo(price)
+	}
+
+	TelemetryProviderSpread(
+		p.endpoints.Name,
+		pair.String(),
+		float32(spread.MustFloat64()),
+	)
+
+	maxSpread, found := p.endpoints.OrderBook.MaxSpreads[pair.String()]
+	if found && spread.GT(maxSpread) {
+		p.logger.Warn().
+			Str("pair", pair.String()).
+			Str("spread", spread.String()).
+			Str("max_spread", maxSpread.String()).
+			Msg("order book spread too wide")
+		delete(p.books, pair.String())
+		return
+	}
+
+	if p.books == nil {
+		p.books = map[string]orderBookPrice{}
+	}
+
+	p.books[pair.String()] = orderBookPrice{
+		price:  price,
+		spread: spread,
+		time:   timestamp,
+	}
+}
+
+func (p *provider) removeOrderBookPrice(symbol string) {
+	pair, found := p.getPair(symbol)
+	if !found {
+		return
+	}
+
+	_, inverse := p.inverse[symbol]
+	if inverse {
+		pair = pair.Swap()
+	}
+
+	delete(p.books, pair.String())
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOrderBook(t *testing.T) {
+	var raw struct {
+		Bids [][]json.RawMessage `json:"bids"`
+		Asks [][]json.RawMessage `json:"asks"`
+	}
+
+	content := `{"bids":[["9.9","10"],["10","5",1688671834]],"asks":[[10.2,"20"],["10.1","10"]]}`
+	require.NoError(t, json.Unmarshal([]byte(content), &raw))
+
+	book, err := NewOrderBook(raw.Bids, raw.Asks)
+	require.NoError(t, err)
+
+	require.Equal(t, math.LegacyMustNewDecFromStr("10"), book.Bids[0].Price)
+	require.Equal(t, math.LegacyMustNewDecFromStr("10.1"), book.Asks[0].Price)
+
+	mid, err := book.MidPrice()
+	require.NoError(t, err)
+	require.Equal(t, math.LegacyMustNewDecFromStr("10.05"), mid)
+
+	spread, err := book.Spread()
+	require.NoError(t, err)
+	require.Equal(t, math.LegacyMustNewDecFromStr("0.1").Quo(mid), spread)
+
+	_, err = NewOrderBook([][]json.RawMessage{{json.RawMessage(`"1"`)}}, nil)
+	require.Error(t, err)
+
+	_, err = OrderBook{}.MidPrice()
+	require.Error(t, err)
+}
+
+func TestOrderBook_DepthWeightedPrice(t *testing.T) {
+	book := OrderBook{
+		Bids: []OrderBookLevel{
+			{Price: math.LegacyNewDec(10), Amount: math.LegacyNewDec(10)},
+			{Price: math.LegacyNewDec(8), Amount: math.LegacyNewDec(100)},
+		},
+		Asks: []OrderBookLevel{
+			{Price: math.LegacyNewDec(12), Amount: math.LegacyNewDec(100)},
+		},
+	}
+
+	// sell: 100 at 10 for 10 units, 80 at 8 for 10 units => 180 / 20 = 9
+	// buy: 180 at 12 => 12
+	price, err := book.DepthWeightedPrice(math.LegacyNewDec(180))
+	require.NoError(t, err)
+	require.Equal(t, math.LegacyMustNewDecFromStr("10.5"), price)
+
+	_, err = book.DepthWeightedPrice(math.LegacyNewDec(10000))
+	require.Error(t, err)
+}
+
+func TestProvider_setOrderBookPrice(t *testing.T) {
+	pair := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	p := provider{
+		logger:  zerolog.Nop(),
+		tickers: map[string]types.TickerPrice{},
+		pairs:   map[string]types.CurrencyPair{"ATOMUSDT": pair},
+		inverse: map[string]types.CurrencyPair{},
+		endpoints: Endpoint{
+			Name: ProviderBinance,
+			OrderBook: &OrderBookOptions{
+				MaxSpreads: map[string]math.LegacyDec{
+					"ATOMUSDT": math.LegacyMustNewDecFromStr("0.01"),
+				},
+			},
+		},
+	}
+	p.setOrderBookHandler(func(string, int) (OrderBook, error) {
+		return OrderBook{}, nil
+	})
+
+	now := time.Now()
+	p.setTickerPrice("ATOMUSDT", math.LegacyNewDec(11), math.LegacyNewDec(100), now)
+
+	narrow := OrderBook{
+		Bids: []OrderBookLevel{{Price: math.LegacyMustNewDecFromStr("9.99"), Amount: math.LegacyOneDec()}},
+		Asks: []OrderBookLevel{{Price: math.LegacyMustNewDecFromStr("10.01"), Amount: math.LegacyOneDec()}},
+	}
+	p.setOrderBookPrice("ATOMUSDT", narrow, now)
+
+	prices, err := p.GetTickerPrices(pair)
+	require.NoError(t, err)
+	require.Equal(t, math.LegacyNewDec(10), prices["ATOMUSDT"].Price)
+	require.Equal(t, math.LegacyNewDec(100), prices["ATOMUSDT"].Volume)
+
+	wide := OrderBook{
+		Bids: []OrderBookLevel{{Price: math.LegacyNewDec(9), Amount: math.LegacyOneDec()}},
+		Asks: []OrderBookLevel{{Price: math.LegacyNewDec(11), Amount: math.LegacyOneDec()}},
+	}
+	p.setOrderBookPrice("ATOMUSDT", wide, now)
+
+	prices, err = p.GetTickerPrices(pair)
+	require.NoError(t, err)
+	require.Empty(t, prices)
+}
@@ -40,6 +40,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderOsmosis,
+			Defaults: osmosisDefaultEndpoints,
+			New:      withoutDb(NewOsmosisProvider),
+		},
+	)
+}
+
 func NewOsmosisProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -59,7 +69,7 @@ func NewOsmosisProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, nil)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
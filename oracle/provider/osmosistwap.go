@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+)
+
+const (
+	OsmosisTwapArithmetic = "arithmetic"
+	OsmosisTwapGeometric  = "geometric"
+
+	osmosisTwapDefaultWindow = 10 * time.Minute
+)
+
+var (
+	_                           Provider = (*OsmosisTwapProvider)(nil)
+	osmosistwapDefaultEndpoints          = Endpoint{
+		Name:         ProviderOsmosisTwap,
+		Urls:         []string{},
+		PollInterval: 6 * time.Second,
+		VolumeBlocks: 4,
+		VolumePause:  0,
+	}
+)
+
+type (
+	// OsmosisTwapProvider defines an oracle provider using the time weighted
+	// average prices of the osmosis x/twap module instead of spot prices.
+	// Pools are configured like for osmosisv2, the window of a pool is set
+	// by the twap windows of the endpoint.
+	//
+	// REF: https://github.com/osmosis-labs/osmosis/tree/main/x/twap
+	OsmosisTwapProvider struct {
+		OsmosisV2Provider
+	}
+
+	OsmosisTwapResponse struct {
+		ArithmeticTwap string `json:"arithmetic_twap"`
+		GeometricTwap  string `json:"geometric_twap"`
+	}
+)
+
+func init() {
+	Register(
+		Registration{
+			Name:          ProviderOsmosisTwap,
+			Defaults:      osmosistwapDefaultEndpoints,
+			New:           withDb(NewOsmosisTwapProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+	)
+}
+
+func NewOsmosisTwapProvider(
+	db *sql.DB,
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*OsmosisTwapProvider, error) {
+	provider := &OsmosisTwapProvider{}
+	provider.db = db
+	provider.Init(
+		ctx,
+		endpoints,
+		logger,
+		pairs,
+		nil,
+		nil,
+	)
+
+	switch provider.endpoints.Twap {
+	case "":
+		provider.endpoints.Twap = OsmosisTwapArithmetic
+	case OsmosisTwapArithmetic, OsmosisTwapGeometric:
+	default:
+		return nil, fmt.Errorf("unsupported twap: %s", provider.endpoints.Twap)
+	}
+
+	availablePairs, _ := provider.GetAvailablePairs()
+	provider.setPairs(pairs, availablePairs, nil)
+
+	// resolve the denoms of the pools
+	err := provider.init()
+	if err != nil {
+		return nil, err
+	}
+
+	provider.startPolling(provider)
+
+	return provider, nil
+}
+
+func (p *OsmosisTwapProvider) Poll() error {
+	p.updateVolumes()
+
+	timestamp := time.Now()
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for symbol, pair := range p.getAllPairs() {
+		poolId, err := p.getContractAddress(pair)
+		if err != nil {
+			p.logger.Warn().
+				Str("symbol", symbol).
+				Msg("no pool id found")
+			continue
+		}
+
+		// windows are configured for the symbol of the pool
+		window := p.window(p.contracts[poolId])
+
+		_, found := p.inverse[symbol]
+		if found {
+			pair = pair.Swap()
+		}
+
+		price, err := p.queryTwap(pair, poolId, timestamp.Add(-window))
+		if err != nil {
+			p.logger.Err(err).Str("symbol", symbol).Msg("failed to get twap")
+			continue
+		}
+
+		volume, _ := p.volumes.Get(pair.String())
+		// hack to get the proper volume
+		_, found = p.inverse[symbol]
+		if found {
+			if !volume.IsZero() {
+				volume = volume.Quo(price)
+			}
+		}
+
+		if volume.IsNil() {
+			volume = math.LegacyZeroDec()
+		}
+
+		p.setTickerPrice(
+			symbol,
+			price,
+			volume,
+			timestamp,
+		)
+
+		liquidity, err := p.getLiquidity(pair, poolId, price)
+		if err != nil {
+			continue
+		}
+
+		p.setTickerLiquidity(symbol, liquidity)
+	}
+
+	p.logger.Debug().Msg("updated tickers")
+	return nil
+}
+
+// queryTwap returns the twap of the base asset in the quote asset of a pool
+// from the start time until now.
+func (p *OsmosisTwapProvider) queryTwap(
+	pair types.CurrencyPair,
+	poolId string,
+	start time.Time,
+) (math.LegacyDec, error) {
+	baseDenom, found := p.denoms[pair.Base]
+	if !found {
+		return math.LegacyDec{}, fmt.Errorf("denom not found for %s", pair.Base)
+	}
+
+	quoteDenom, found := p.denoms[pair.Quote]
+	if !found {
+		return math.LegacyDec{}, fmt.Errorf("denom not found for %s", pair.Quote)
+	}
+
+	method := "ArithmeticTwapToNow"
+	if p.endpoints.Twap == OsmosisTwapGeometric {
+		method = "GeometricTwapToNow"
+	}
+
+	query := url.Values{}
+	query.Set("pool_id", poolId)
+	query.Set("base_asset", baseDenom)
+	query.Set("quote_asset", quoteDenom)
+	query.Set("start_time", start.UTC().Format(time.RFC3339))
+
+	content, err := p.httpGet("/osmosis/twap/v1beta1/" + method + "?" + query.Encode())
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	var response OsmosisTwapResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return math.LegacyDec{}, p.error(err)
+	}
+
+	twap := response.ArithmeticTwap
+	if p.endpoints.Twap == OsmosisTwapGeometric {
+		twap = response.GeometricTwap
+	}
+
+	price := strToDec(twap)
+	if price.IsNil() {
+		return math.LegacyDec{}, p.errorf("failed parsing twap")
+	}
+
+	return price, nil
+}
+
+func (p *OsmosisTwapProvider) window(symbol string) time.Duration {
+	window, found := p.endpoints.TwapWindows[symbol]
+	if !found || window <= 0 {
+		return osmosisTwapDefaultWindow
+	}
+	return window
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOsmosisTwapProvider_Poll(t *testing.T) {
+	atomDenom := "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/osmosis/gamm/v1beta1/pools/1":
+			fmt.Fprintf(rw, `{"pool":{"@type":"/osmosis.gamm.v1beta1.Pool","pool_assets":[`+
+				`{"token":{"denom":"%s","amount":"1000"}},{"token":{"denom":"uosmo","amount":"8500"}}]}}`,
+				atomDenom,
+			)
+		case "/osmosis/twap/v1beta1/GeometricTwapToNow":
+			query := req.URL.Query()
+			if query.Get("pool_id") != "1" ||
+				query.Get("base_asset") != atomDenom ||
+				query.Get("quote_asset") != "uosmo" {
+				t.Errorf("unexpected twap query %s", req.URL.RawQuery)
+				http.Error(rw, "unexpected query", http.StatusBadRequest)
+				return
+			}
+
+			start, err := time.Parse(time.RFC3339, query.Get("start_time"))
+			if err != nil {
+				t.Errorf("invalid start time: %v", err)
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if window := time.Since(start); window < 4*time.Minute || window > 6*time.Minute {
+				t.Errorf("unexpected twap window %s", window)
+				http.Error(rw, "unexpected start time", http.StatusBadRequest)
+				return
+			}
+
+			fmt.Fprint(rw, `{"geometric_twap":"8.500000000000000000"}`)
+		default:
+			http.NotFound(rw, req)
+		}
+	}))
+	defer server.Close()
+
+	pair := types.CurrencyPair{Base: "ATOM", Quote: "OSMO"}
+
+	endpoints := Endpoint{
+		Name:              ProviderOsmosisTwap,
+		Urls:              []string{server.URL},
+		PollInterval:      50 * time.Millisecond,
+		ContractAddresses: map[string]string{"ATOMOSMO": "1"},
+		TwapWindows:       map[string]time.Duration{"ATOMOSMO": 5 * time.Minute},
+		Twap:              OsmosisTwapGeometric,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, err := NewOsmosisTwapProvider(nil, ctx, zerolog.Nop(), endpoints, pair)
+	require.NoError(t, err)
+
+	var prices map[string]types.TickerPrice
+	require.Eventually(t, func() bool {
+		prices, err = p.GetTickerPrices(pair)
+		return err == nil && len(prices) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.Equal(t, math.LegacyMustNewDecFromStr("8.5"), prices["ATOMOSMO"].Price)
+}
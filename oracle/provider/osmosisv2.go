@@ -59,10 +59,27 @@ type (
 	}
 
 	OsmosisV2Token struct {
-		Denom string `json:"denom"`
+		Denom  string `json:"denom"`
+		Amount string `json:"amount,omitempty"`
+	}
+
+	OsmosisV2PoolLiquidityResponse struct {
+		Liquidity []OsmosisV2Token `json:"liquidity"`
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:          ProviderOsmosisV2,
+			Defaults:      osmosisv2DefaultEndpoints,
+			New:           withDb(NewOsmosisV2Provider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+	)
+}
+
 func NewOsmosisV2Provider(
 	db *sql.DB,
 	ctx context.Context,
@@ -89,7 +106,7 @@ func NewOsmosisV2Provider(
 		return nil, err
 	}
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 
 	return provider, nil
 }
@@ -97,7 +114,11 @@ func NewOsmosisV2Provider(
 func (p *OsmosisV2Provider) Poll() error {
 	p.updateVolumes()
 
-	timestamp := time.Now()
+	timestamp, err := p.getCosmosBlockTime()
+	if err != nil {
+		p.logger.Warn().Err(err).Msg("failed to get block time")
+		timestamp = time.Now()
+	}
 
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -150,6 +171,17 @@ func (p *OsmosisV2Provider) Poll() error {
 			volume,
 			timestamp,
 		)
+
+		liquidity, err := p.getLiquidity(pair, poolId, price)
+		if err != nil {
+			p.logger.Warn().
+				Err(err).
+				Str("symbol", symbol).
+				Msg("failed to get pool liquidity")
+			continue
+		}
+
+		p.setTickerLiquidity(symbol, liquidity)
 	}
 
 	p.logger.Debug().Msg("updated tickers")
@@ -227,6 +259,59 @@ func (p *OsmosisV2Provider) queryConcentratedLiquidityPool(
 	return price.Power(2), nil
 }
 
+// getLiquidity returns the pool reserves valued in the quote asset of the
+// provided pair, the price is expected to be quoted the same way.
+func (p *OsmosisV2Provider) getLiquidity(
+	pair types.CurrencyPair,
+	poolId string,
+	price math.LegacyDec,
+) (math.LegacyDec, error) {
+	path := "/osmosis/poolmanager/v1beta1/pools/" + poolId + "/total_pool_liquidity"
+
+	content, err := p.httpGet(path)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	var response OsmosisV2PoolLiquidityResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return math.LegacyDec{}, p.error(err)
+	}
+
+	reserves := map[string]math.LegacyDec{}
+	for _, token := range response.Liquidity {
+		symbol, found := p.denoms[token.Denom]
+		if !found {
+			continue
+		}
+
+		decimals, found := p.endpoints.Decimals[symbol]
+		if !found {
+			return math.LegacyDec{}, fmt.Errorf("no decimals found")
+		}
+
+		amount := strToDec(token.Amount)
+		if amount.IsNil() {
+			return math.LegacyDec{}, p.errorf("failed parsing pool liquidity")
+		}
+
+		reserves[symbol] = amount.Quo(uintToDec(10).Power(uint64(decimals)))
+	}
+
+	base, found := reserves[pair.Base]
+	if !found {
+		return math.LegacyDec{}, fmt.Errorf("pool reserves not found")
+	}
+
+	quote, found := reserves[pair.Quote]
+	if !found {
+		return math.LegacyDec{}, fmt.Errorf("pool reserves not found")
+	}
+
+	return poolLiquidity(base, quote, price), nil
+}
+
 // Get denoms for "legacy" pools, set map for concentrated liquidity
 func (p *OsmosisV2Provider) init() error {
 	p.denoms = map[string]string{}
@@ -71,6 +71,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderPancakeV3Bsc,
+			Defaults: PancakeV3BscDefaultEndpoints,
+			New:      withoutDb(NewPancakeProvider),
+		},
+	)
+}
+
 func NewPancakeProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -94,7 +104,7 @@ func NewPancakeProvider(
 
 	provider.init()
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -64,6 +64,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderPhemex,
+			Defaults: phemexDefaultEndpoints,
+			New:      withoutDb(NewPhemexProvider),
+		},
+	)
+}
+
 func NewPhemexProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -119,7 +129,8 @@ func NewPhemexProvider(
 		len(provider.getAllPairs())*1700+2000,
 	) * time.Millisecond
 
-	go startPolling(provider, interval, logger)
+	provider.endpoints.PollInterval = interval
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -35,6 +35,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderPionex,
+			Defaults: pionexDefaultEndpoints,
+			New:      withoutDb(NewPionexProvider),
+		},
+	)
+}
+
 func NewPionexProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -54,7 +64,7 @@ func NewPionexProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToPionexSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -0,0 +1,387 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"sync"
+	"time"
+
+	"price-feeder/oracle/types"
+	"price-feeder/pkg/plugin"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+)
+
+const (
+	pluginReadyTimeout   = 10 * time.Second
+	pluginReconnectDelay = 5 * time.Second
+)
+
+var (
+	_                      Provider = (*PluginProvider)(nil)
+	pluginDefaultEndpoints          = Endpoint{
+		Name:         ProviderPlugin,
+		Urls:         []string{},
+		PollInterval: 5 * time.Second,
+	}
+)
+
+type (
+	// PluginOptions defines how to reach an out-of-process provider, either
+	// by launching Command and talking on its stdin and stdout, or by
+	// dialing the tcp Address.
+	PluginOptions struct {
+		Command []string
+		Address string
+	}
+
+	// PluginProvider defines an oracle provider that receives its tickers
+	// from an out-of-process plugin speaking the protocol of pkg/plugin.
+	PluginProvider struct {
+		provider
+		conn     *plugin.Conn
+		messages chan plugin.Message
+		closer   func()
+
+		// requested are the configured and subscribed pairs, available
+		// the ones the plugin supports according to its last ready message
+		requested []types.CurrencyPair
+		available map[string]struct{}
+	}
+)
+
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderPlugin,
+			Defaults: pluginDefaultEndpoints,
+			New:      withoutDb(NewPluginProvider),
+			Custom:   true,
+		},
+	)
+}
+
+func NewPluginProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*PluginProvider, error) {
+	if endpoints.Plugin == nil {
+		return nil, fmt.Errorf("no plugin options for %s", endpoints.Name)
+	}
+
+	provider := &PluginProvider{}
+	provider.Init(
+		ctx,
+		endpoints,
+		logger,
+		pairs,
+		nil,
+		nil,
+	)
+
+	provider.requested = pairs
+
+	ready, err := provider.connect(pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.mtx.Lock()
+	provider.setReadyPairs(ready)
+	provider.mtx.Unlock()
+
+	go provider.run()
+
+	return provider, nil
+}
+
+// connect launches or dials the plugin and negotiates the pairs.
+func (p *PluginProvider) connect(pairs []types.CurrencyPair) (plugin.Message, error) {
+	options := p.endpoints.Plugin
+
+	var (
+		reader io.Reader
+		writer io.Writer
+		closer func()
+	)
+
+	switch {
+	case options.Address != "":
+		netConn, err := net.DialTimeout("tcp", options.Address, pluginReadyTimeout)
+		if err != nil {
+			return plugin.Message{}, err
+		}
+
+		stop := make(chan struct{})
+		go func() {
+			select {
+			case <-p.ctx.Done():
+			case <-stop:
+			}
+			netConn.Close()
+		}()
+
+		reader, writer = netConn, netConn
+		closer = sync.OnceFunc(func() { close(stop) })
+	case len(options.Command) > 0:
+		cmd := exec.CommandContext(p.ctx, options.Command[0], options.Command[1:]...)
+
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return plugin.Message{}, err
+		}
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return plugin.Message{}, err
+		}
+		stderr, err := cmd.StderrPipe()
+		if err != nil {
+			return plugin.Message{}, err
+		}
+
+		err = cmd.Start()
+		if err != nil {
+			return plugin.Message{}, err
+		}
+
+		go func() {
+			scanner := bufio.NewScanner(stderr)
+			for scanner.Scan() {
+				p.logger.Info().Str("stderr", scanner.Text()).Msg("plugin")
+			}
+		}()
+
+		reader, writer = stdout, stdin
+		// closing stdin asks the plugin to exit, it is killed if it doesn't
+		closer = sync.OnceFunc(func() {
+			stdin.Close()
+
+			done := make(chan struct{})
+			go func() {
+				_ = cmd.Wait()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(pluginReadyTimeout):
+				_ = cmd.Process.Kill()
+				<-done
+			}
+		})
+	default:
+		return plugin.Message{}, fmt.Errorf("plugin needs a command or an address")
+	}
+
+	conn := plugin.NewConn(reader, writer)
+	messages := make(chan plugin.Message)
+
+	go func() {
+		defer close(messages)
+		for {
+			message, err := conn.Receive()
+			if err != nil {
+				p.logger.Warn().Err(err).Msg("plugin disconnected")
+				return
+			}
+			select {
+			case messages <- message:
+			case <-p.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	requested := make([]plugin.Pair, len(pairs))
+	for i, pair := range pairs {
+		requested[i] = plugin.Pair{Base: pair.Base, Quote: pair.Quote}
+	}
+
+	err := conn.Send(plugin.Message{
+		Type:         plugin.MessageInit,
+		Version:      plugin.Version,
+		Name:         p.endpoints.Name.String(),
+		Pairs:        requested,
+		PollInterval: p.endpoints.PollInterval.String(),
+	})
+	if err != nil {
+		closer()
+		return plugin.Message{}, err
+	}
+
+	timeout := time.After(pluginReadyTimeout)
+	for {
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				closer()
+				return plugin.Message{}, fmt.Errorf("plugin disconnected before ready")
+			}
+			switch message.Type {
+			case plugin.MessageReady:
+				if message.Version != plugin.Version {
+					closer()
+					return plugin.Message{}, fmt.Errorf("unsupported plugin version %d", message.Version)
+				}
+
+				p.mtx.Lock()
+				p.conn = conn
+				p.messages = messages
+				p.closer = closer
+				p.mtx.Unlock()
+
+				return message, nil
+			case plugin.MessageError:
+				p.logger.Error().Str("error", message.Error).Msg("plugin")
+			}
+		case <-timeout:
+			closer()
+			return plugin.Message{}, fmt.Errorf("plugin not ready after %s", pluginReadyTimeout)
+		}
+	}
+}
+
+// run handles the messages of the plugin and reconnects after disconnects.
+func (p *PluginProvider) run() {
+	for {
+		for message := range p.messages {
+			p.handleMessage(message)
+		}
+
+		p.mtx.Lock()
+		p.conn = nil
+		p.mtx.Unlock()
+		p.closer()
+
+		for {
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-time.After(pluginReconnectDelay):
+			}
+
+			// pairs rejected before are requested again
+			p.mtx.RLock()
+			pairs := append([]types.CurrencyPair{}, p.requested...)
+			p.mtx.RUnlock()
+
+			ready, err := p.connect(pairs)
+			if err == nil {
+				p.mtx.Lock()
+				p.setReadyPairs(ready)
+				p.mtx.Unlock()
+
+				p.logger.Info().Msg("plugin reconnected")
+				break
+			}
+			p.logger.Err(err).Msg("failed to reconnect plugin")
+		}
+	}
+}
+
+func (p *PluginProvider) handleMessage(message plugin.Message) {
+	switch message.Type {
+	case plugin.MessageTickers:
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+
+		for _, ticker := range message.Tickers {
+			symbol := ticker.Base + ticker.Quote
+			if _, found := p.getPair(symbol); !found {
+				p.logger.Warn().Str("symbol", symbol).Msg("ticker of unknown pair")
+				continue
+			}
+
+			price := strToDec(ticker.Price)
+			if price.IsNil() {
+				p.logger.Error().Str("symbol", symbol).Msg("failed parsing price")
+				continue
+			}
+
+			volume := math.LegacyZeroDec()
+			if ticker.Volume != "" {
+				volume = strToDec(ticker.Volume)
+				if volume.IsNil() {
+					p.logger.Error().Str("symbol", symbol).Msg("failed parsing volume")
+					continue
+				}
+			}
+
+			timestamp := time.Now()
+			if ticker.Time > 0 {
+				timestamp = time.UnixMilli(ticker.Time)
+			}
+
+			p.setTickerPrice(symbol, price, volume, timestamp)
+		}
+	case plugin.MessageReady:
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+
+		p.setReadyPairs(message)
+		p.logger.Info().Int("pairs", len(message.Pairs)).Msg("plugin ready")
+	case plugin.MessageError:
+		p.logger.Error().Str("error", message.Error).Msg("plugin")
+	default:
+		p.logger.Warn().Str("type", message.Type).Msg("unknown plugin message")
+	}
+}
+
+// setReadyPairs applies the pairs the plugin supports of the requested
+// pairs, must be called with the lock held.
+func (p *PluginProvider) setReadyPairs(ready plugin.Message) {
+	p.available = map[string]struct{}{}
+	for _, pair := range ready.Pairs {
+		p.available[pair.String()] = struct{}{}
+	}
+	p.setPairs(p.requested, p.available, nil)
+}
+
+// SubscribeCurrencyPairs requests new pairs from the plugin, they are
+// applied once the plugin answers with a ready message.
+func (p *PluginProvider) SubscribeCurrencyPairs(pairs ...types.CurrencyPair) error {
+	p.mtx.Lock()
+	requested := map[types.CurrencyPair]struct{}{}
+	for _, pair := range p.requested {
+		requested[pair] = struct{}{}
+	}
+	newPairs := []types.CurrencyPair{}
+	for _, pair := range pairs {
+		if _, found := requested[pair]; !found {
+			newPairs = append(newPairs, pair)
+		}
+	}
+	p.requested = append(p.requested, newPairs...)
+	conn := p.conn
+	p.mtx.Unlock()
+
+	// disconnected plugins are sent all requested pairs on reconnect
+	if len(newPairs) == 0 || conn == nil {
+		return nil
+	}
+
+	subscribe := make([]plugin.Pair, len(newPairs))
+	for i, pair := range newPairs {
+		subscribe[i] = plugin.Pair{Base: pair.Base, Quote: pair.Quote}
+	}
+
+	return conn.Send(plugin.Message{Type: plugin.MessageSubscribe, Pairs: subscribe})
+}
+
+func (p *PluginProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	symbols := make(map[string]struct{}, len(p.available))
+	for symbol := range p.available {
+		symbols[symbol] = struct{}{}
+	}
+	return symbols, nil
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"price-feeder/oracle/types"
+	"price-feeder/pkg/plugin"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type testPluginSource struct {
+	mtx       sync.Mutex
+	supported map[string]struct{}
+}
+
+func (s *testPluginSource) support(symbol string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.supported[symbol] = struct{}{}
+}
+
+func (s *testPluginSource) Pairs(requested []plugin.Pair) ([]plugin.Pair, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	pairs := []plugin.Pair{}
+	for _, pair := range requested {
+		if _, found := s.supported[pair.String()]; found {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs, nil
+}
+
+func (*testPluginSource) Tickers(pairs []plugin.Pair) ([]plugin.Ticker, error) {
+	tickers := []plugin.Ticker{}
+	for _, pair := range pairs {
+		tickers = append(tickers, plugin.Ticker{
+			Base:   pair.Base,
+			Quote:  pair.Quote,
+			Price:  "12.3456",
+			Volume: "100",
+			Time:   time.Now().UnixMilli(),
+		})
+	}
+	return tickers, nil
+}
+
+func TestPluginProvider_GetTickerPrices(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := &testPluginSource{supported: map[string]struct{}{"ATOMUSDT": {}}}
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = plugin.Serve(ctx, conn, conn, source)
+	}()
+
+	atomUsdt := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	btcUsdt := types.CurrencyPair{Base: "BTC", Quote: "USDT"}
+
+	endpoints := Endpoint{
+		Name:         "example",
+		Type:         ProviderPlugin,
+		Urls:         []string{"tcp://" + listener.Addr().String()},
+		PollInterval: 50 * time.Millisecond,
+		Plugin:       &PluginOptions{Address: listener.Addr().String()},
+	}
+
+	p, err := NewPluginProvider(ctx, zerolog.Nop(), endpoints, atomUsdt, btcUsdt)
+	require.NoError(t, err)
+
+	// only the pairs negotiated with the plugin are available
+	available, err := p.GetAvailablePairs()
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"ATOMUSDT": {}}, available)
+
+	var prices map[string]types.TickerPrice
+	require.Eventually(t, func() bool {
+		prices, err = p.GetTickerPrices(atomUsdt)
+		return err == nil && len(prices) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.Equal(t, testAtomPriceDec, prices["ATOMUSDT"].Price)
+	require.Equal(t, math.LegacyNewDec(100), prices["ATOMUSDT"].Volume)
+
+	// pairs are applied by the ready answer to a subscribe
+	ethUsdt := types.CurrencyPair{Base: "ETH", Quote: "USDT"}
+	source.support("ETHUSDT")
+	require.NoError(t, p.SubscribeCurrencyPairs(ethUsdt))
+
+	require.Eventually(t, func() bool {
+		prices, err = p.GetTickerPrices(ethUsdt)
+		return err == nil && len(prices) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	available, err = p.GetAvailablePairs()
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"ATOMUSDT": {}, "ETHUSDT": {}}, available)
+}
@@ -36,6 +36,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderPoloniex,
+			Defaults: poloniexDefaultEndpoints,
+			New:      withoutDb(NewPoloniexProvider),
+		},
+	)
+}
+
 func NewPoloniexProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -55,7 +65,7 @@ func NewPoloniexProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToPoloniexSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -31,6 +32,7 @@ import (
 const (
 	defaultTimeout       = 10 * time.Second
 	staleTickersCutoff   = 1 * time.Minute
+	clockSkewSmoothing   = 8 // weight of the previous skew against a new sample
 	providerCandlePeriod = 10 * time.Minute
 
 	ProviderAstroportInjective Name = "astroport_injective"
@@ -40,7 +42,6 @@ const (
 	ProviderBinanceUS          Name = "binanceus"
 	ProviderBingx              Name = "bingx"
 	ProviderBitfinex           Name = "bitfinex"
-	ProviderBitforex           Name = "bitforex"
 	ProviderBitget             Name = "bitget"
 	ProviderBitmart            Name = "bitmart"
 	ProviderBitstamp           Name = "bitstamp"
@@ -48,6 +49,7 @@ const (
 	ProviderBybit              Name = "bybit"
 	ProviderCamelotV2          Name = "camelotv2"
 	ProviderCamelotV3          Name = "camelotv3"
+	ProviderChainlink          Name = "chainlink"
 	ProviderCoinbase           Name = "coinbase"
 	ProviderCoinex             Name = "coinex"
 	ProviderCrypto             Name = "crypto"
@@ -56,6 +58,7 @@ const (
 	ProviderFin                Name = "fin"
 	ProviderFinV2              Name = "finv2"
 	ProviderGate               Name = "gate"
+	ProviderGenericRest        Name = "generic_rest"
 	ProviderHelix              Name = "helix"
 	ProviderHitBtc             Name = "hitbtc"
 	ProviderHuobi              Name = "huobi"
@@ -69,13 +72,16 @@ const (
 	ProviderOkx                Name = "okx"
 	ProviderOsmosis            Name = "osmosis"
 	ProviderOsmosisV2          Name = "osmosisv2"
+	ProviderOsmosisTwap        Name = "osmosistwap"
 	ProviderPancakeV3Bsc       Name = "pancakev3_bsc"
 	ProviderPhemex             Name = "phemex"
 	ProviderPionex             Name = "pionex"
 	ProviderPoloniex           Name = "poloniex"
 	ProviderPyth               Name = "pyth"
 	ProviderShade              Name = "shade"
+	ProviderPlugin             Name = "plugin"
 	ProviderStride             Name = "stride"
+	ProviderUniswapV2          Name = "uniswapv2"
 	ProviderUniswapV3          Name = "uniswapv3"
 	ProviderUnstake            Name = "unstake"
 	ProviderVelodromeV2        Name = "velodromev2"
@@ -113,6 +119,7 @@ type (
 		name      string
 		endpoints Endpoint
 		httpBase  string
+		health    *endpointHealth
 		http      *http.Client
 		logger    zerolog.Logger
 		mtx       sync.RWMutex
@@ -125,6 +132,22 @@ type (
 		volumes   volume.VolumeHandler
 		height    uint64
 		chain     string
+
+		// arguments of setPairs, used again to add pairs
+		availablePairs   map[string]struct{}
+		toProviderSymbol CurrencyPairToProviderSymbol
+
+		books            map[string]orderBookPrice
+		orderBookHandler OrderBookHandler
+
+		trades       map[string]map[string]Trade
+		tradeHandler TradeHandler
+		tradeStream  bool
+
+		// smoothed difference between the local clock and the timestamps
+		// reported by the source, see ClockSkew
+		skew        time.Duration
+		skewSamples int
 	}
 
 	PollingProvider interface {
@@ -144,6 +167,8 @@ type (
 	// hardcoded rest and websocket api endpoints.
 	Endpoint struct {
 		Name              Name // ex. "binance"
+		Type              Name // provider of named endpoints, ex. "uniswapv2"
+		Chain             string
 		Urls              []string
 		Websocket         string // ex. "stream.binance.com:9443"
 		WebsocketPath     string
@@ -156,6 +181,18 @@ type (
 		VolumePause       int
 		Decimals          map[string]int
 		Periods           map[string]int
+		Generic           *GenericRestOptions
+		OrderBook         *OrderBookOptions
+		Trades            *TradeOptions
+		Twap              string                   // ex. "arithmetic" or "geometric"
+		Heartbeats        map[string]time.Duration // max age of chainlink rounds per pair
+		TwapWindows       map[string]time.Duration // osmosistwap window per pair
+		MaxAges           map[string]time.Duration // max age of stride redemption rates per pair
+		Plugin            *PluginOptions
+		RateLimit         *RateLimitOptions
+		Http              *HttpOptions
+		Hedge             time.Duration // delay before racing the second best url, 0 disables hedging
+		MaxHeightLag      uint64        // blocks behind the highest url to demote a url
 	}
 
 	EvmLog struct {
@@ -190,7 +227,12 @@ func (p *provider) Init(
 
 	p.logger = logger.With().Str("provider", p.endpoints.Name.String()).Logger()
 	p.tickers = map[string]types.TickerPrice{}
-	p.http = newDefaultHTTPClient()
+	client, err := newHTTPClient(p.endpoints.Http)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("invalid http options")
+		client = newDefaultHTTPClient()
+	}
+	p.http = client
 
 	if len(p.endpoints.Urls) == 0 {
 		p.logger.Error().Msg("no endpoint urls found")
@@ -198,14 +240,28 @@ func (p *provider) Init(
 	}
 	p.httpBase = p.endpoints.Urls[0]
 
+	p.health = newEndpointHealth(p.endpoints.Urls, p.endpoints.MaxHeightLag)
+	if len(p.endpoints.Urls) > 1 {
+		go p.runHealthChecks(ctx)
+	}
+
 	p.contracts = endpoints.ContractAddresses
 
-	if p.endpoints.Websocket != "" {
+	// websockets are only set up for providers that handle messages and
+	// are started by the provider once its pairs are known
+	if p.endpoints.Websocket != "" && websocketMessageHandler != nil {
 		websocketUrl := url.URL{
 			Scheme: "wss",
 			Host:   p.endpoints.Websocket,
 			Path:   p.endpoints.WebsocketPath,
 		}
+		// allow overriding the scheme, e.g. "ws://localhost:8080"
+		parsed, err := url.Parse(p.endpoints.Websocket)
+		if err == nil && parsed.Host != "" {
+			websocketUrl.Scheme = parsed.Scheme
+			websocketUrl.Host = parsed.Host
+		}
+		p.endpoints.Http.setQuery(&websocketUrl)
 		p.websocket = NewWebsocketController(
 			ctx,
 			p.endpoints.Name,
@@ -218,7 +274,14 @@ func (p *provider) Init(
 			p.endpoints.PingMessage,
 			p.logger,
 		)
-		go p.websocket.Start()
+		if p.endpoints.Http != nil {
+			dialer, header, err := p.endpoints.Http.websocketDialer()
+			if err != nil {
+				p.logger.Error().Err(err).Msg("invalid http options")
+			} else {
+				p.websocket.SetDialer(dialer, header)
+			}
+		}
 	}
 
 	// set contract<>symbol mapping
@@ -284,11 +347,26 @@ func (p *provider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]type
 					Msg("ticker price is '0'")
 				continue
 			}
+			if p.isOrderBookMode() {
+				book, found := p.books[symbol]
+				if !found || time.Since(book.time) > staleTickersCutoff {
+					p.logger.Warn().
+						Str("pair", symbol).
+						Msg("missing order book price")
+					continue
+				}
+				price.Price = book.price
+			}
 			if time.Since(price.Time) > staleTickersCutoff {
 				p.logger.Warn().
 					Str("pair", symbol).
 					Time("time", price.Time).
 					Msg("tickers data is stale")
+			} else if time.Until(price.Time) > staleTickersCutoff {
+				p.logger.Warn().
+					Str("pair", symbol).
+					Time("time", price.Time).
+					Msg("tickers timestamp is in the future")
 			} else {
 				tickers[symbol] = price
 			}
@@ -297,24 +375,78 @@ func (p *provider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]type
 	return tickers, nil
 }
 
+// recordClockSkew adds the difference between the local clock and a
+// timestamp reported by the source to the smoothed clock skew, must be
+// called with the lock held. Frozen and far future data is recorded too,
+// so that a large skew shows up even though the tickers are stale, only
+// missing timestamps are ignored.
+func (p *provider) recordClockSkew(timestamp time.Time) {
+	if timestamp.IsZero() {
+		return
+	}
+	sample := time.Since(timestamp)
+
+	if p.skewSamples == 0 {
+		p.skew = sample
+	} else {
+		p.skew += (sample - p.skew) / clockSkewSmoothing
+	}
+	p.skewSamples++
+
+	telemetryProviderClockSkew(p.endpoints.Name, p.skew)
+}
+
+// ClockSkew returns how far the timestamps reported by the source lag
+// behind the local clock, including the delivery latency. Negative values
+// mean the source clock is ahead.
+func (p *provider) ClockSkew() time.Duration {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return p.skew
+}
+
+// SubscribeCurrencyPairs adds pairs to a running provider and subscribes to
+// them on its websocket. Providers without a websocket only poll the pairs
+// they were created with and have to be restarted instead.
 func (p *provider) SubscribeCurrencyPairs(pairs ...types.CurrencyPair) error {
+	if p.websocket == nil {
+		return fmt.Errorf("%s requires a restart to add pairs", p.endpoints.Name)
+	}
+
 	p.mtx.Lock()
-	defer p.mtx.Unlock()
 	newPairs := p.addPairs(pairs...)
-	if p.endpoints.Websocket == "" {
+	p.mtx.Unlock()
+
+	if len(newPairs) == 0 {
 		return nil
 	}
 	return p.websocket.AddPairs(newPairs)
 }
 
+// addPairs adds the pairs that are not configured yet and returns them, must
+// be called with the lock held.
 func (p *provider) addPairs(pairs ...types.CurrencyPair) []types.CurrencyPair {
+	configured := map[types.CurrencyPair]struct{}{}
+	allPairs := []types.CurrencyPair{}
+	for _, pair := range p.getAllPairs() {
+		if _, found := configured[pair]; !found {
+			configured[pair] = struct{}{}
+			allPairs = append(allPairs, pair)
+		}
+	}
+
 	newPairs := []types.CurrencyPair{}
 	for _, pair := range pairs {
-		_, ok := p.pairs[pair.String()]
-		if !ok {
+		if _, found := configured[pair]; !found {
+			configured[pair] = struct{}{}
 			newPairs = append(newPairs, pair)
 		}
 	}
+
+	if len(newPairs) > 0 {
+		p.setPairs(append(allPairs, newPairs...), p.availablePairs, p.toProviderSymbol)
+	}
 	return newPairs
 }
 
@@ -407,6 +539,8 @@ func (p *provider) getCosmosTx(hash string) (types.CosmosTx, error) {
 }
 
 func (p *provider) getCosmosHeight() (uint64, error) {
+	p.health.setHeightCheck(heightCheckCosmos)
+
 	path := "/cosmos/base/tendermint/v1beta1/blocks/latest"
 	content, err := p.httpGet(path)
 	if err != nil {
@@ -428,6 +562,24 @@ func (p *provider) getCosmosHeight() (uint64, error) {
 	return height, nil
 }
 
+// getCosmosBlockTime returns the time of the latest block.
+func (p *provider) getCosmosBlockTime() (time.Time, error) {
+	path := "/cosmos/base/tendermint/v1beta1/blocks/latest"
+	content, err := p.httpGet(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var response types.CosmosBlockResponse
+
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Parse(time.RFC3339, response.Block.Header.Time)
+}
+
 func (p *provider) getCosmosTxs(
 	height uint64,
 	msgTypes []string,
@@ -559,47 +711,87 @@ func (p *provider) httpPost(path string, body []byte) ([]byte, error) {
 }
 
 func (p *provider) httpRequest(path string, method string, body []byte, headers map[string]string) ([]byte, error) {
-	res, err := p.makeHttpRequest(p.httpBase+path, method, body, headers)
-	if err != nil {
-		index := 0
-		urls := []string{}
-
-		for i, url := range p.endpoints.Urls {
-			if p.httpBase == url {
-				index = i
-				break
+	urls := p.health.urls()
+	if len(urls) == 0 {
+		urls = []string{p.httpBase}
+		for _, url := range p.endpoints.Urls {
+			if url != p.httpBase {
+				urls = append(urls, url)
 			}
 		}
+	}
 
-		urls = append(urls, p.endpoints.Urls[index+1:]...)
-		urls = append(urls, p.endpoints.Urls[:index]...)
+	var (
+		content []byte
+		err     error
+	)
 
-		for _, endpoint := range urls {
+	// race the two best endpoints, then fall back to the others
+	if p.endpoints.Hedge > 0 && len(urls) > 1 {
+		var url string
+		content, url, err = p.hedgedRequest([2]string{urls[0], urls[1]}, path, method, body, headers)
+		if err == nil {
+			p.httpBase = url
+			return content, nil
+		}
+		urls = urls[2:]
+	}
+
+	for i, url := range urls {
+		if i > 0 || err != nil {
 			p.logger.Warn().
-				Str("endpoint", endpoint).
+				Str("endpoint", url).
 				Msg("trying alternate http endpoints")
+		}
 
-			res, err = p.makeHttpRequest(endpoint+path, method, body, headers)
-			if err == nil {
-				p.logger.Info().Str("endpoint", endpoint).Msg("selected alternate http endpoint")
-				p.httpBase = endpoint
-				break
+		start := time.Now()
+		content, err = p.makeHttpRequest(url+path, method, body, headers)
+		p.health.record(url, time.Since(start), err)
+		if err == nil {
+			if url != p.httpBase {
+				p.logger.Info().Str("endpoint", url).Msg("selected alternate http endpoint")
+				p.httpBase = url
 			}
+			return content, nil
 		}
 	}
-	return res, err
+	return nil, err
 }
 
 func (p *provider) makeHttpRequest(url string, method string, body []byte, headers map[string]string) ([]byte, error) {
+	limiter := getRateLimiter(p.logger, p.endpoints.Name, url, p.endpoints.RateLimit)
+	if until, limited := limiter.cooldown(); limited {
+		telemetryHttpThrottled(p.endpoints.Name, "cooldown")
+		return nil, fmt.Errorf("%w until %s", ErrRateLimited, until.Format(time.RFC3339))
+	}
+
 	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
 
+	p.endpoints.Http.setQuery(req.URL)
+	p.endpoints.Http.setHeaders(req.Header)
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
 
+	ctx := p.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	delay, err := limiter.wait(ctx, limiter.weight(req.URL.Path))
+	if delay > 0 {
+		telemetryHttpThrottled(p.endpoints.Name, "wait")
+		p.logger.Debug().
+			Dur("delay", delay).
+			Str("url", url).
+			Msg("http request delayed by rate limit")
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := p.http.Do(req)
 	if err != nil {
 		p.logger.Warn().
@@ -607,6 +799,21 @@ func (p *provider) makeHttpRequest(url string, method string, body []byte, heade
 			Msg("http request failed")
 		return nil, err
 	}
+	defer res.Body.Close()
+
+	if until, limited := limiter.update(res); limited {
+		reason := "weight"
+		if res.StatusCode != 200 {
+			reason = "status"
+		}
+		telemetryHttpThrottled(p.endpoints.Name, reason)
+		p.logger.Warn().
+			Int("code", res.StatusCode).
+			Str("url", url).
+			Str("reason", reason).
+			Time("until", until).
+			Msg("http ratelimited")
+	}
 
 	if res.StatusCode != 200 {
 		p.logger.Warn().
@@ -615,12 +822,6 @@ func (p *provider) makeHttpRequest(url string, method string, body []byte, heade
 			Str("url", url).
 			Str("method", method).
 			Msg("http request returned invalid status")
-		if res.StatusCode == 429 || res.StatusCode == 418 {
-			p.logger.Warn().
-				Str("url", url).
-				Str("retry_after", res.Header.Get("Retry-After")).
-				Msg("http ratelimited")
-		}
 		return nil, fmt.Errorf("http request returned invalid status")
 	}
 	content, err := io.ReadAll(res.Body)
@@ -634,120 +835,31 @@ func (p *provider) makeHttpRequest(url string, method string, body []byte, heade
 	return content, nil
 }
 
+// skipPoll reports whether all http endpoints are in a rate limit cooldown,
+// in which case the poll is skipped.
+func (p *provider) skipPoll() bool {
+	if len(p.endpoints.Urls) == 0 {
+		return false
+	}
+	for _, url := range p.endpoints.Urls {
+		_, limited := getRateLimiter(p.logger, p.endpoints.Name, url, p.endpoints.RateLimit).cooldown()
+		if !limited {
+			return false
+		}
+	}
+	telemetryHttpThrottled(p.endpoints.Name, "poll")
+	return true
+}
+
+// SetDefaults fills the unset fields of the endpoint with the defaults of
+// its registered provider.
 func (e *Endpoint) SetDefaults() {
-	var defaults Endpoint
-	switch e.Name {
-	case ProviderAstroportInjective:
-		defaults = astroportInjectiveDefaultEndpoints
-	case ProviderAstroportNeutron:
-		defaults = astroportNeutronDefaultEndpoints
-	case ProviderAstroportTerra2:
-		defaults = astroportTerra2DefaultEndpoints
-	case ProviderBinance:
-		defaults = binanceDefaultEndpoints
-	case ProviderBitfinex:
-		defaults = bitfinexDefaultEndpoints
-	case ProviderBinanceUS:
-		defaults = binanceUSDefaultEndpoints
-	case ProviderBingx:
-		defaults = bingxDefaultEndpoints
-	case ProviderBitget:
-		defaults = bitgetDefaultEndpoints
-	case ProviderBitmart:
-		defaults = bitmartDefaultEndpoints
-	case ProviderBitstamp:
-		defaults = bitstampDefaultEndpoints
-	case ProviderBkex:
-		defaults = bkexDefaultEndpoints
-	case ProviderBybit:
-		defaults = bybitDefaultEndpoints
-	case ProviderCamelotV2:
-		defaults = camelotV2DefaultEndpoints
-	case ProviderCamelotV3:
-		defaults = camelotV3DefaultEndpoints
-	case ProviderCoinbase:
-		defaults = coinbaseDefaultEndpoints
-	case ProviderCoinex:
-		defaults = coinexDefaultEndpoints
-	case ProviderCrypto:
-		defaults = cryptoDefaultEndpoints
-	case ProviderCurve:
-		defaults = curveDefaultEndpoints
-	case ProviderDexter:
-		defaults = dexterDefaultEndpoints
-	case ProviderFin:
-		defaults = finDefaultEndpoints
-	case ProviderFinV2:
-		defaults = finV2DefaultEndpoints
-	case ProviderGate:
-		defaults = gateDefaultEndpoints
-	case ProviderHelix:
-		defaults = helixDefaultEndpoints
-	case ProviderHitBtc:
-		defaults = hitbtcDefaultEndpoints
-	case ProviderIdxOsmosis:
-		defaults = idxOsmosisDefaultEndpoints
-	case ProviderHuobi:
-		defaults = huobiDefaultEndpoints
-	case ProviderKraken:
-		defaults = krakenDefaultEndpoints
-	case ProviderKucoin:
-		defaults = kucoinDefaultEndpoints
-	case ProviderLbank:
-		defaults = lbankDefaultEndpoints
-	case ProviderMexc:
-		defaults = mexcDefaultEndpoints
-	case ProviderMaya:
-		defaults = mayaDefaultEndpoints
-	case ProviderMock:
-		defaults = mockDefaultEndpoints
-	case ProviderOkx:
-		defaults = okxDefaultEndpoints
-	case ProviderOsmosis:
-		defaults = osmosisDefaultEndpoints
-	case ProviderOsmosisV2:
-		defaults = osmosisv2DefaultEndpoints
-	case ProviderPancakeV3Bsc:
-		defaults = PancakeV3BscDefaultEndpoints
-	case ProviderPhemex:
-		defaults = phemexDefaultEndpoints
-	case ProviderPionex:
-		defaults = pionexDefaultEndpoints
-	case ProviderPoloniex:
-		defaults = poloniexDefaultEndpoints
-	case ProviderPyth:
-		defaults = pythDefaultEndpoints
-	case ProviderShade:
-		defaults = shadeDefaultEndpoints
-	case ProviderUniswapV3:
-		defaults = uniswapv3DefaultEndpoints
-	case ProviderUnstake:
-		defaults = unstakeDefaultEndpoints
-	case ProviderVelodromeV2:
-		defaults = velodromev2DefaultEndpoints
-	case ProviderWhitewhaleCmdx:
-		defaults = whitewhaleCmdxDefaultEndpoints
-	case ProviderWhitewhaleHuahua:
-		defaults = whitewhaleHuahuaDefaultEndpoints
-	case ProviderWhitewhaleInj:
-		defaults = whitewhaleInjDefaultEndpoints
-	case ProviderWhitewhaleJuno:
-		defaults = whitewhaleJunoDefaultEndpoints
-	case ProviderWhitewhaleLunc:
-		defaults = whitewhaleLuncDefaultEndpoints
-	case ProviderWhitewhaleLuna:
-		defaults = whitewhaleLunaDefaultEndpoints
-	case ProviderWhitewhaleSei:
-		defaults = whitewhaleSeiDefaultEndpoints
-	case ProviderWhitewhaleWhale:
-		defaults = whitewhaleWhaleDefaultEndpoints
-	case ProviderXt:
-		defaults = xtDefaultEndpoints
-	case ProviderZero:
-		defaults = zeroDefaultEndpoints
-	default:
+	registration, found := Lookup(e.TypeName())
+	if !found {
 		return
 	}
+	defaults := registration.Defaults
+
 	if e.Urls == nil {
 		urls := defaults.Urls
 		rand.Seed(time.Now().UnixNano())
@@ -757,7 +869,7 @@ func (e *Endpoint) SetDefaults() {
 		)
 		e.Urls = urls
 	}
-	if e.Websocket == "" && defaults.Websocket != "" { // don't enable websockets for providers that don't support them
+	if e.Websocket == "" && registration.Websocket { // don't enable websockets for providers that don't support them
 		e.Websocket = defaults.Websocket
 	}
 	if e.WebsocketPath == "" {
@@ -795,17 +907,67 @@ func (e *Endpoint) SetDefaults() {
 	if e.VolumePause <= 0 {
 		e.VolumePause = defaults.VolumePause
 	}
+
+	if e.RateLimit == nil {
+		e.RateLimit = defaults.RateLimit
+	}
 }
 
-func startPolling(p PollingProvider, interval time.Duration, logger zerolog.Logger) {
-	logger.Debug().Dur("interval", interval).Msg("starting poll loop")
-	for {
-		err := p.Poll()
-		if err != nil {
-			logger.Error().Err(err).Msg("failed to poll")
+// startWebsocket connects the websocket of the provider, if any. Polling
+// acts as fallback while the websocket is not streaming.
+func (p *provider) startWebsocket() {
+	// last trade prices are not used in order book mode and in trade mode
+	// only if the provider streams trades
+	if p.websocket == nil || p.isOrderBookMode() {
+		return
+	}
+	if p.isTradeMode() && !p.tradeStream {
+		return
+	}
+	go p.websocket.Start()
+}
+
+// isStreaming returns true if the websocket is streaming and no ticker
+// is older than half of the stale tickers cutoff, e.g. due to pairs that
+// are not updated by the websocket.
+func (p *provider) isStreaming() bool {
+	if p.websocket == nil || !p.websocket.IsStreaming() {
+		return false
+	}
+
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	for _, pair := range p.getAllPairs() {
+		ticker, found := p.tickers[pair.String()]
+		if !found || time.Since(ticker.Time) > staleTickersCutoff/2 {
+			return false
 		}
-		time.Sleep(interval)
 	}
+
+	return true
+}
+
+// websocketSymbols returns the provider symbols of the given pairs.
+func (p *provider) websocketSymbols(pairs ...types.CurrencyPair) []string {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	requested := map[types.CurrencyPair]struct{}{}
+	for _, pair := range pairs {
+		requested[pair] = struct{}{}
+	}
+
+	symbols := []string{}
+	for symbol, pair := range p.getAllPairs() {
+		_, found := requested[pair]
+		if found {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	sort.Strings(symbols)
+	return symbols
 }
 
 func (p *provider) setPairs(
@@ -821,6 +983,8 @@ func (p *provider) setPairs(
 			return pair.String()
 		}
 	}
+	p.availablePairs = availablePairs
+	p.toProviderSymbol = toProviderSymbol
 
 	if availablePairs == nil {
 		p.logger.Warn().Msg("available pairs not provided")
@@ -866,6 +1030,19 @@ func (p *provider) setTickerPrice(
 	price math.LegacyDec,
 	volume math.LegacyDec,
 	timestamp time.Time,
+) {
+	p.setTickerPriceWithUnit(symbol, price, volume, types.VolumeUnitBase, timestamp)
+}
+
+// setTickerPriceWithUnit stores the ticker for the provider symbol. The
+// volume unit is relative to the provider symbol, for inverted pairs it is
+// translated so that the stored ticker's unit refers to the configured pair.
+func (p *provider) setTickerPriceWithUnit(
+	symbol string,
+	price math.LegacyDec,
+	volume math.LegacyDec,
+	unit types.VolumeUnit,
+	timestamp time.Time,
 ) {
 	if price.IsNil() || price.LTE(math.LegacyZeroDec()) {
 		p.logger.Warn().
@@ -880,16 +1057,24 @@ func (p *provider) setTickerPrice(
 			Msg("volume is zero")
 	}
 
+	p.recordClockSkew(timestamp)
+
 	// check if price needs to be inverted
 	pair, inverse := p.inverse[symbol]
 	if inverse {
-		volume = volume.Mul(price)
+		switch unit {
+		case types.VolumeUnitBase:
+			volume = volume.Mul(price)
+		case types.VolumeUnitQuote:
+			unit = types.VolumeUnitBase
+		}
 		price = invertDec(price)
 
 		p.tickers[pair.String()] = types.TickerPrice{
-			Price:  price,
-			Volume: volume,
-			Time:   timestamp,
+			Price:      price,
+			Volume:     volume,
+			VolumeUnit: unit,
+			Time:       timestamp,
 		}
 
 		TelemetryProviderPrice(
@@ -911,9 +1096,10 @@ func (p *provider) setTickerPrice(
 	}
 
 	p.tickers[pair.String()] = types.TickerPrice{
-		Price:  price,
-		Volume: volume,
-		Time:   timestamp,
+		Price:      price,
+		Volume:     volume,
+		VolumeUnit: unit,
+		Time:       timestamp,
 	}
 
 	TelemetryProviderPrice(
@@ -924,6 +1110,45 @@ func (p *provider) setTickerPrice(
 	)
 }
 
+// setTickerLiquidity attaches the pool liquidity, denominated in the quote
+// asset of the provider symbol, to the ticker set for that symbol.
+func (p *provider) setTickerLiquidity(symbol string, liquidity math.LegacyDec) {
+	if liquidity.IsNil() || liquidity.IsNegative() {
+		return
+	}
+
+	pair, inverse := p.inverse[symbol]
+	if !inverse {
+		var found bool
+		pair, found = p.pairs[symbol]
+		if !found {
+			p.logger.Error().
+				Str("symbol", symbol).
+				Msg("symbol not found")
+			return
+		}
+	}
+
+	ticker, found := p.tickers[pair.String()]
+	if !found {
+		return
+	}
+
+	// liquidity is denominated in the base asset of an inverted pair
+	if inverse {
+		liquidity = liquidity.Mul(ticker.Price)
+	}
+
+	ticker.Liquidity = liquidity
+	p.tickers[pair.String()] = ticker
+
+	TelemetryProviderLiquidity(
+		p.endpoints.Name,
+		pair.String(),
+		float32(liquidity.MustFloat64()),
+	)
+}
+
 func (p *provider) isPair(symbol string) bool {
 	if _, found := p.pairs[symbol]; found {
 		return true
@@ -1082,6 +1307,53 @@ func (p *provider) getEthDecimals(contract string) (uint64, error) {
 	return decimals, nil
 }
 
+// getErc20Balance returns the raw token balance of an account.
+func (p *provider) getErc20Balance(token, account string) (math.LegacyDec, error) {
+	address := strings.TrimPrefix(strings.ToLower(account), "0x")
+	args := []string{fmt.Sprintf("%064s", address)}
+
+	response, err := p.evmCall(token, "balanceOf(address)", args)
+	if err != nil {
+		return math.LegacyDec{}, err
+	}
+
+	var data string
+	err = json.Unmarshal(response, &data)
+	if err != nil {
+		return math.LegacyDec{}, p.error(err)
+	}
+
+	decoded, err := decodeEthData(data, []string{"uint256"})
+	if err != nil {
+		return math.LegacyDec{}, p.error(err)
+	}
+
+	return strToDec(fmt.Sprintf("%v", decoded[0])), nil
+}
+
+// getEvmPoolLiquidity returns the value of the token balances held by a
+// pool contract, denominated in token1. The price is token1 per token0.
+func (p *provider) getEvmPoolLiquidity(
+	pool string,
+	tokens [2]string,
+	decimals [2]uint64,
+	price math.LegacyDec,
+) (math.LegacyDec, error) {
+	reserves := [2]math.LegacyDec{}
+	for i, token := range tokens {
+		balance, err := p.getErc20Balance(token, pool)
+		if err != nil {
+			return math.LegacyDec{}, err
+		}
+		if balance.IsNil() {
+			return math.LegacyDec{}, p.errorf("failed parsing balance")
+		}
+		reserves[i] = balance.Quo(uintToDec(10).Power(decimals[i]))
+	}
+
+	return poolLiquidity(reserves[0], reserves[1], price), nil
+}
+
 func (p *provider) evmRpcQuery(method, params string) (json.RawMessage, error) {
 	p.logger.Info().Str("method", method).Msg("query evm rpc")
 
@@ -1119,6 +1391,7 @@ func (p *provider) evmRpcQuery(method, params string) (json.RawMessage, error) {
 
 func (p *provider) getEvmHeight() (uint64, error) {
 	p.logger.Info().Msg("get height")
+	p.health.setHeightCheck(heightCheckEvm)
 
 	result, err := p.evmRpcQuery("eth_blockNumber", "")
 	if err != nil {
@@ -1187,6 +1460,91 @@ func (p *provider) evmGetLogs(
 	return logs, nil
 }
 
+// updateEvmVolumes calls update for the blocks since the last call and for
+// up to VolumeBlocks ranges of missing blocks. The first call only sets the
+// starting height.
+func (p *provider) updateEvmVolumes(update func(from, to uint64) error) error {
+	height, err := p.getEvmHeight()
+	if err != nil {
+		return err
+	}
+
+	if p.height == 0 {
+		p.height = height
+		return nil
+	}
+
+	// some rpc providers only accept small ranges for getLogs calls
+	if p.height+2000 < height {
+		p.height = height - 2000
+	}
+
+	err = update(p.height, height)
+	p.height = height
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < p.endpoints.VolumeBlocks; i++ {
+		missing := p.volumes.GetMissing(1)
+		if len(missing) == 0 {
+			break
+		}
+
+		to := missing[0]
+
+		var from uint64
+		if to > 2000 {
+			from = to - 2000
+		}
+
+		err = update(from, to)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newEvmVolumes returns zero volumes of all volume symbols for the blocks
+// after height1 up to and including height2, with interpolated block times.
+func (p *provider) newEvmVolumes(height1, height2 uint64) ([]volume.Volume, error) {
+	blocks := height2 - height1
+	height1 = height1 + 1
+
+	timestamps := make([]time.Time, 2)
+	for i, height := range []uint64{height1, height2} {
+		block, err := p.evmGetBlockByNumber(height)
+		if err != nil {
+			return nil, err
+		}
+		timestamps[i], err = block.GetTime()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blocktime := timestamps[1].Sub(timestamps[0]).Seconds() / float64(blocks)
+	timestamp := timestamps[0].Unix()
+
+	volumes := make([]volume.Volume, blocks)
+	for i := uint64(0); i < blocks; i++ {
+		values := map[string]math.LegacyDec{}
+		for _, symbol := range p.volumes.Symbols() {
+			values[symbol] = math.LegacyZeroDec()
+		}
+
+		volumes[i] = volume.Volume{
+			Height: height1 + i,
+			Time:   timestamp + int64(float64(i)*blocktime),
+			Values: values,
+		}
+	}
+
+	return volumes, nil
+}
+
 func (p *provider) evmGetBlockByNumber(height uint64) (EvmBlock, error) {
 	params := fmt.Sprintf(`"0x%x",false`, height)
 	output, err := p.evmRpcQuery("eth_getBlockByNumber", params)
@@ -1204,6 +1562,23 @@ func (p *provider) evmGetBlockByNumber(height uint64) (EvmBlock, error) {
 	return block, nil
 }
 
+// getEvmBlockTime returns the time of the latest block.
+func (p *provider) getEvmBlockTime() (time.Time, error) {
+	output, err := p.evmRpcQuery("eth_getBlockByNumber", `"latest",false`)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var block EvmBlock
+
+	err = json.Unmarshal(output, &block)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return block.GetTime()
+}
+
 func (p *provider) evmCall(
 	address, method string,
 	args []string,
@@ -1245,6 +1620,11 @@ func hexToUint64(s string) (uint64, error) {
 	return strconv.ParseUint(strings.Replace(s, "0x", "", -1), 16, 64)
 }
 
+// poolLiquidity returns the value of both pool reserves in the quote asset.
+func poolLiquidity(base, quote, price math.LegacyDec) math.LegacyDec {
+	return base.Mul(price).Add(quote)
+}
+
 func decodeSqrtPrice(sqrt string) (math.LegacyDec, error) {
 	dec := strToDec(sqrt)
 	if dec.IsZero() {
@@ -1371,6 +1751,15 @@ func floatToDec(f float64) math.LegacyDec {
 	return strToDec(strconv.FormatFloat(f, 'f', -1, 64))
 }
 
+// unixMilliOrNow converts a source timestamp in milliseconds, sources that
+// omit it get the local time instead of the unix epoch.
+func unixMilliOrNow(milliseconds int64) time.Time {
+	if milliseconds == 0 {
+		return time.Now()
+	}
+	return time.UnixMilli(milliseconds)
+}
+
 func invertDec(d math.LegacyDec) math.LegacyDec {
 	if d.IsZero() || d.IsNil() {
 		return math.LegacyZeroDec()
@@ -86,3 +86,38 @@ func TestStrToDec(t *testing.T) {
 		require.Equal(t, math.LegacyDec{}, dec)
 	})
 }
+
+func TestProvider_SourceTimestamps(t *testing.T) {
+	p := newTestWebsocketProvider("ATOMUSDT")
+
+	// the source clock lags behind by 10 seconds
+	p.setTickerPrice("ATOMUSDT", testAtomPriceDec, testAtomVolumeDec, time.Now().Add(-10*time.Second))
+	require.InDelta(t, 10*time.Second, p.ClockSkew(), float64(time.Second))
+
+	prices, err := p.GetTickerPrices(testAtomUsdtCurrencyPair)
+	require.NoError(t, err)
+	require.Contains(t, prices, "ATOMUSDT")
+
+	// frozen data is stale even if it was just received, but still moves
+	// the clock skew: 10s + (120s - 10s) / 8
+	p.setTickerPrice("ATOMUSDT", testAtomPriceDec, testAtomVolumeDec, time.Now().Add(-2*staleTickersCutoff))
+	prices, err = p.GetTickerPrices(testAtomUsdtCurrencyPair)
+	require.NoError(t, err)
+	require.Empty(t, prices)
+	require.InDelta(t, 23750*time.Millisecond, p.ClockSkew(), float64(time.Second))
+
+	// as are timestamps too far in the future: 23.75s + (-120s - 23.75s) / 8
+	p.setTickerPrice("ATOMUSDT", testAtomPriceDec, testAtomVolumeDec, time.Now().Add(2*staleTickersCutoff))
+	prices, err = p.GetTickerPrices(testAtomUsdtCurrencyPair)
+	require.NoError(t, err)
+	require.Empty(t, prices)
+	require.InDelta(t, 5781*time.Millisecond, p.ClockSkew(), float64(time.Second))
+
+	// missing timestamps don't affect the clock skew
+	skew := p.ClockSkew()
+	p.setTickerPrice("ATOMUSDT", testAtomPriceDec, testAtomVolumeDec, time.Time{})
+	require.Equal(t, skew, p.ClockSkew())
+
+	// sources that omit the timestamp get the local time, not the unix epoch
+	require.WithinDuration(t, time.Now(), unixMilliOrNow(0), time.Second)
+}
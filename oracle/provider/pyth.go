@@ -42,6 +42,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderPyth,
+			Defaults: pythDefaultEndpoints,
+			New:      withoutDb(NewPythProvider),
+		},
+	)
+}
+
 func NewPythProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -61,7 +71,7 @@ func NewPythProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToPythSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -110,15 +120,11 @@ func (p *PythProvider) Poll() error {
 
 		price := strToDec(ticker.Price.Price).Mul(factor)
 
-		// Use now for testing, as only crypto is available 24/7
-		// timestamp := time.Unix(ticker.Price.Time, 0)
-		timestamp := time.Now()
-
 		p.setTickerPrice(
 			ticker.ID,
 			price,
 			math.LegacyNewDec(1),
-			timestamp,
+			time.Unix(ticker.Price.Time, 0),
 		)
 	}
 
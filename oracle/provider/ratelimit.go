@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultRateLimitCooldown = 30 * time.Second
+	defaultWeightInterval    = time.Minute
+	// used weights above this share of the max weight start a cooldown
+	rateLimitWeightThreshold = 0.9
+)
+
+// ErrRateLimited is returned for requests to hosts in cooldown.
+var ErrRateLimited = errors.New("rate limited")
+
+var (
+	rateLimitersMtx sync.Mutex
+	rateLimiters    = map[string]*rateLimiter{}
+)
+
+type (
+	// RateLimitOptions defines the request budget of a host. Requests is
+	// the number of tokens refilled per Interval, each request takes the
+	// weight of the longest matching path prefix in Weights or 1.
+	// WeightHeader is a response header with the weight used within
+	// WeightInterval (ex.: "X-MBX-USED-WEIGHT-1M"), the host is put in
+	// cooldown until the end of the interval once it nears MaxWeight.
+	RateLimitOptions struct {
+		Requests       int
+		Interval       time.Duration
+		Weights        map[string]int
+		WeightHeader   string
+		MaxWeight      int
+		WeightInterval time.Duration
+	}
+
+	// rateLimiter is a token bucket shared by all providers of a host. The
+	// options are set by the first provider that configures them.
+	rateLimiter struct {
+		mtx       sync.Mutex
+		provider  Name
+		source    *RateLimitOptions
+		options   RateLimitOptions
+		conflicts map[Name]struct{}
+		tokens    float64
+		capacity  float64
+		rate      float64 // tokens per second
+		last      time.Time
+		until     time.Time
+	}
+)
+
+// getRateLimiter returns the limiter of the host of rawUrl. Changed options
+// of the provider that configured the limiter, e.g. after a reload, replace
+// the options, differing options of other providers are ignored with a
+// warning.
+func getRateLimiter(
+	logger zerolog.Logger,
+	name Name,
+	rawUrl string,
+	options *RateLimitOptions,
+) *rateLimiter {
+	host := rawUrl
+	parsed, err := url.Parse(rawUrl)
+	if err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+
+	rateLimitersMtx.Lock()
+	defer rateLimitersMtx.Unlock()
+
+	limiter, found := rateLimiters[host]
+	if !found {
+		limiter = newRateLimiter(nil)
+		rateLimiters[host] = limiter
+	}
+	if options == nil {
+		return limiter
+	}
+	if owner, conflict := limiter.configure(name, options); conflict {
+		logger.Warn().
+			Str("host", host).
+			Str("configured_by", owner.String()).
+			Msg("ignoring differing rate limit options for shared host")
+	}
+	return limiter
+}
+
+func newRateLimiter(options *RateLimitOptions) *rateLimiter {
+	limiter := &rateLimiter{last: time.Now()}
+	if options != nil {
+		limiter.setOptions(options)
+	}
+	return limiter
+}
+
+// configure applies the options of a provider. It returns the provider
+// whose options are used and true if they conflict with the given options
+// for the first time.
+func (l *rateLimiter) configure(name Name, options *RateLimitOptions) (Name, bool) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.source == options {
+		return l.provider, false
+	}
+
+	if l.source == nil || l.provider == name {
+		// restarted providers with unchanged options keep their tokens
+		if l.source == nil || !reflect.DeepEqual(*l.source, *options) {
+			l.setOptions(options)
+		}
+		l.provider = name
+		l.source = options
+		return l.provider, false
+	}
+
+	if reflect.DeepEqual(*l.source, *options) {
+		return l.provider, false
+	}
+	if _, found := l.conflicts[name]; found {
+		return l.provider, false
+	}
+	if l.conflicts == nil {
+		l.conflicts = map[Name]struct{}{}
+	}
+	l.conflicts[name] = struct{}{}
+	return l.provider, true
+}
+
+// setOptions resets the token bucket to the given options, must be called
+// with the lock held.
+func (l *rateLimiter) setOptions(options *RateLimitOptions) {
+	l.options = *options
+	if l.options.WeightInterval == 0 {
+		l.options.WeightInterval = defaultWeightInterval
+	}
+
+	l.capacity, l.tokens, l.rate = 0, 0, 0
+	if options.Requests > 0 && options.Interval > 0 {
+		l.capacity = float64(options.Requests)
+		l.tokens = l.capacity
+		l.rate = l.capacity / options.Interval.Seconds()
+	}
+}
+
+// weight returns the weight of a request to path.
+func (l *rateLimiter) weight(path string) int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	weight, length := 1, -1
+	for prefix, w := range l.options.Weights {
+		if strings.HasPrefix(path, prefix) && len(prefix) > length {
+			weight, length = w, len(prefix)
+		}
+	}
+	return weight
+}
+
+// cooldown returns the end of the current cooldown, if any.
+func (l *rateLimiter) cooldown() (time.Time, bool) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	return l.until, time.Now().Before(l.until)
+}
+
+// setCooldown blocks requests until the given time.
+func (l *rateLimiter) setCooldown(until time.Time) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if until.After(l.until) {
+		l.until = until
+	}
+}
+
+// reserve takes the tokens of a request and returns the time to wait
+// before sending it.
+func (l *rateLimiter) reserve(weight int) time.Duration {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.rate == 0 {
+		return 0
+	}
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+	l.last = now
+
+	l.tokens -= float64(weight)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait blocks until a request of the given weight may be sent.
+func (l *rateLimiter) wait(ctx context.Context, weight int) (time.Duration, error) {
+	delay := l.reserve(weight)
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return delay, ctx.Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
+
+// update starts a cooldown if the response is rate limited or the used
+// weight nears the maximum and returns its end.
+func (l *rateLimiter) update(res *http.Response) (time.Time, bool) {
+	now := time.Now()
+
+	retryAfter, found := parseRetryAfter(res.Header.Get("Retry-After"), now)
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		// binance answers 418 once an ip is banned for ignoring 429s
+		if !found {
+			retryAfter = now.Add(defaultRateLimitCooldown)
+		}
+		l.setCooldown(retryAfter)
+		return retryAfter, true
+	case http.StatusServiceUnavailable:
+		if found {
+			l.setCooldown(retryAfter)
+			return retryAfter, true
+		}
+	}
+
+	l.mtx.Lock()
+	options := l.options
+	l.mtx.Unlock()
+
+	if options.WeightHeader == "" || options.MaxWeight <= 0 {
+		return time.Time{}, false
+	}
+
+	used, err := strconv.Atoi(res.Header.Get(options.WeightHeader))
+	if err != nil {
+		return time.Time{}, false
+	}
+	if float64(used) < float64(options.MaxWeight)*rateLimitWeightThreshold {
+		return time.Time{}, false
+	}
+
+	// used weights are reset at the start of each interval
+	until := now.Truncate(options.WeightInterval).Add(options.WeightInterval)
+	l.setCooldown(until)
+	return until, true
+}
+
+// parseRetryAfter parses the seconds or the http date of a Retry-After
+// header.
+func parseRetryAfter(value string, now time.Time) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date, true
+	}
+	return time.Time{}, false
+}
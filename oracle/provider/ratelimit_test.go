@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	limiter := newRateLimiter(&RateLimitOptions{
+		Requests: 10,
+		Interval: time.Second,
+		Weights:  map[string]int{"/api": 2, "/api/heavy": 5},
+	})
+
+	require.Equal(t, 1, limiter.weight("/other"))
+	require.Equal(t, 2, limiter.weight("/api/light"))
+	require.Equal(t, 5, limiter.weight("/api/heavy?limit=10"))
+
+	require.Zero(t, limiter.reserve(5))
+	require.Zero(t, limiter.reserve(5))
+	// the bucket is empty, 5 tokens refill in half a second
+	delay := limiter.reserve(5)
+	require.InDelta(t, 500*time.Millisecond, delay, float64(50*time.Millisecond))
+}
+
+func TestRateLimiter_Update(t *testing.T) {
+	limiter := newRateLimiter(&RateLimitOptions{
+		WeightHeader: "X-MBX-USED-WEIGHT-1M",
+		MaxWeight:    100,
+	})
+
+	res := &http.Response{StatusCode: 200, Header: http.Header{}}
+	res.Header.Set("X-MBX-USED-WEIGHT-1M", "50")
+	_, limited := limiter.update(res)
+	require.False(t, limited)
+
+	res.Header.Set("X-MBX-USED-WEIGHT-1M", "95")
+	until, limited := limiter.update(res)
+	require.True(t, limited)
+	require.True(t, until.After(time.Now()))
+	require.False(t, until.After(time.Now().Add(time.Minute)))
+
+	limiter = newRateLimiter(nil)
+	res = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	res.Header.Set("Retry-After", "120")
+	until, limited = limiter.update(res)
+	require.True(t, limited)
+	require.WithinDuration(t, time.Now().Add(2*time.Minute), until, time.Second)
+
+	cooldown, limited := limiter.cooldown()
+	require.True(t, limited)
+	require.Equal(t, until, cooldown)
+
+	// service unavailable only throttles with a retry after
+	limiter = newRateLimiter(nil)
+	_, limited = limiter.update(&http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}})
+	require.False(t, limited)
+}
+
+func TestGetRateLimiter_Options(t *testing.T) {
+	var logs bytes.Buffer
+	logger := zerolog.New(&logs)
+	url := "https://options.ratelimit.test/api"
+
+	limiter := getRateLimiter(logger, ProviderBinance, url, &RateLimitOptions{Requests: 10, Interval: time.Second})
+	require.Equal(t, float64(10), limiter.capacity)
+
+	// other providers of the host can't change the options
+	other := getRateLimiter(logger, ProviderBinanceUS, url, &RateLimitOptions{Requests: 1, Interval: time.Second})
+	require.Same(t, limiter, other)
+	require.Equal(t, float64(10), limiter.capacity)
+	require.Contains(t, logs.String(), "ignoring differing rate limit options")
+
+	// the provider that configured them can, e.g. after a reload
+	getRateLimiter(logger, ProviderBinance, url, &RateLimitOptions{Requests: 5, Interval: time.Second})
+	require.Equal(t, float64(5), limiter.capacity)
+}
+
+func TestProvider_HttpRateLimited(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		rw.Header().Set("Retry-After", "60")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	p := provider{
+		ctx:    context.Background(),
+		logger: zerolog.Nop(),
+		http:   newDefaultHTTPClient(),
+		endpoints: Endpoint{
+			Name: "test",
+			Urls: []string{server.URL},
+		},
+		httpBase: server.URL,
+	}
+
+	_, err := p.httpGet("/ticker")
+	require.Error(t, err)
+	require.Equal(t, int32(1), requests.Load())
+
+	// requests and polls are skipped during the cooldown
+	_, err = p.httpGet("/ticker")
+	require.True(t, errors.Is(err, ErrRateLimited))
+	require.Equal(t, int32(1), requests.Load())
+	require.True(t, p.skipPoll())
+}
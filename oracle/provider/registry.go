@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+
+	"price-feeder/oracle/types"
+
+	"github.com/rs/zerolog"
+)
+
+type (
+	// Constructor creates a provider for the given endpoint and pairs, db
+	// is nil if no volume history is kept.
+	Constructor func(
+		db *sql.DB,
+		ctx context.Context,
+		logger zerolog.Logger,
+		endpoints Endpoint,
+		pairs ...types.CurrencyPair,
+	) (Provider, error)
+
+	// Registration describes a provider implementation. Every provider
+	// registers itself from its own file, the registry drives the default
+	// endpoints, the config validation and the construction of providers.
+	Registration struct {
+		Name     Name
+		Defaults Endpoint
+		New      Constructor
+		// Websocket is set for providers that stream over websockets.
+		Websocket bool
+		// NeedsDb is set for providers that keep a volume history.
+		NeedsDb bool
+		// NeedsDecimals is set for providers that need the decimals of
+		// their denoms to compute prices or volumes.
+		NeedsDecimals bool
+		// Custom providers are only configured in their own config section,
+		// ex.: [[generic_rest]], and are not valid provider names.
+		Custom bool
+	}
+)
+
+var (
+	registryMtx sync.RWMutex
+	registry    = map[Name]Registration{}
+)
+
+// Register adds providers to the registry, it panics on duplicate names.
+func Register(registrations ...Registration) {
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+
+	for _, registration := range registrations {
+		if registration.Name == "" || registration.New == nil {
+			panic(fmt.Sprintf("invalid provider registration: %q", registration.Name))
+		}
+		if _, found := registry[registration.Name]; found {
+			panic(fmt.Sprintf("provider %s registered twice", registration.Name))
+		}
+		registration.Defaults.Name = registration.Name
+		registry[registration.Name] = registration
+	}
+}
+
+// Lookup returns the registration of a provider.
+func Lookup(name Name) (Registration, bool) {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+
+	registration, found := registry[name]
+	return registration, found
+}
+
+// Registered returns all registrations sorted by name.
+func Registered() []Registration {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+
+	registrations := make([]Registration, 0, len(registry))
+	for _, registration := range registry {
+		registrations = append(registrations, registration)
+	}
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].Name < registrations[j].Name
+	})
+	return registrations
+}
+
+// TypeName returns the name of the registration that implements the
+// endpoint, which differs from its name for custom providers.
+func (e Endpoint) TypeName() Name {
+	switch {
+	case e.Type != "":
+		return e.Type
+	case e.Generic != nil:
+		return ProviderGenericRest
+	default:
+		return e.Name
+	}
+}
+
+// withoutDb adapts the constructor of a provider without volume history.
+func withoutDb[P Provider](
+	constructor func(context.Context, zerolog.Logger, Endpoint, ...types.CurrencyPair) (P, error),
+) Constructor {
+	return func(
+		_ *sql.DB,
+		ctx context.Context,
+		logger zerolog.Logger,
+		endpoints Endpoint,
+		pairs ...types.CurrencyPair,
+	) (Provider, error) {
+		provider, err := constructor(ctx, logger, endpoints, pairs...)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	}
+}
+
+// withDb adapts the constructor of a provider with volume history.
+func withDb[P Provider](
+	constructor func(*sql.DB, context.Context, zerolog.Logger, Endpoint, ...types.CurrencyPair) (P, error),
+) Constructor {
+	return func(
+		db *sql.DB,
+		ctx context.Context,
+		logger zerolog.Logger,
+		endpoints Endpoint,
+		pairs ...types.CurrencyPair,
+	) (Provider, error) {
+		provider, err := constructor(db, ctx, logger, endpoints, pairs...)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	}
+}
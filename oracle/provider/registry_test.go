@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"price-feeder/pkg/plugin"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Defaults(t *testing.T) {
+	for _, registration := range Registered() {
+		require.Equal(t, registration.Name, registration.Defaults.Name)
+		require.Equal(t, registration.Defaults.Websocket != "", registration.Websocket, registration.Name)
+	}
+}
+
+func TestRegistry_New(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = plugin.Serve(ctx, conn, conn, &testPluginSource{supported: map[string]struct{}{}})
+			}()
+		}
+	}()
+
+	for _, registration := range Registered() {
+		endpoints := Endpoint{
+			Name:              registration.Name,
+			Urls:              []string{server.URL},
+			PollInterval:      time.Minute,
+			ContractAddresses: map[string]string{},
+			Decimals:          map[string]int{},
+			Periods:           map[string]int{},
+		}
+		if registration.Websocket {
+			endpoints.Websocket = "ws://" + server.Listener.Addr().String()
+		}
+
+		switch registration.Name {
+		case ProviderGenericRest:
+			endpoints.Generic = &GenericRestOptions{Path: "/tickers", Price: "last"}
+		case ProviderPlugin:
+			endpoints.Plugin = &PluginOptions{Address: listener.Addr().String()}
+		}
+
+		p, err := registration.New(nil, ctx, zerolog.Nop(), endpoints)
+		require.NoError(t, err, registration.Name)
+		require.NotNil(t, p, registration.Name)
+	}
+}
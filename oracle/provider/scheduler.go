@@ -0,0 +1,389 @@
+package provider
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// defaultPollJitter is the share of the poll interval by which every wait
+// is randomly shortened or extended, so that providers don't poll in
+// lockstep.
+const defaultPollJitter = 0.1
+
+type schedulerKey struct{}
+
+// defaultScheduler runs the poll loops of providers created without a
+// scheduler in their context, ex. in tests.
+var defaultScheduler = NewScheduler(zerolog.Nop())
+
+type (
+	// Scheduler owns the poll loops of all providers. Loops are stopped
+	// when the context of their provider is done, when they are removed or
+	// replaced and when the scheduler is stopped. Panics in polls are
+	// recovered and the poll is retried with backoff, providers that keep
+	// panicking are quarantined until resumed.
+	Scheduler struct {
+		logger zerolog.Logger
+		jitter float64
+
+		mtx     sync.RWMutex
+		jobs    map[Name]*pollJob
+		wg      sync.WaitGroup
+		stopped bool
+	}
+
+	// PollStatus reports the state of the poll loop of a provider.
+	PollStatus struct {
+		Interval    time.Duration `json:"interval"`
+		Paused      bool          `json:"paused"`
+		Quarantined bool          `json:"quarantined"`
+		Panics      int           `json:"panics"` // consecutive
+		LastPoll    time.Time     `json:"last_poll"`
+		LastSuccess time.Time     `json:"last_success"`
+		LastError   time.Time     `json:"last_error"`
+		LastPanic   time.Time     `json:"last_panic"`
+		Error       string        `json:"error,omitempty"`
+		ClockSkew   time.Duration `json:"clock_skew"`
+	}
+
+	pollJob struct {
+		name     Name
+		poller   PollingProvider
+		interval time.Duration
+		logger   zerolog.Logger
+		cancel   context.CancelFunc
+		wake     chan struct{}
+
+		mtx    sync.RWMutex
+		status PollStatus
+	}
+)
+
+// NewScheduler returns a scheduler with the default jitter.
+func NewScheduler(logger zerolog.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger.With().Str("module", "scheduler").Logger(),
+		jitter: defaultPollJitter,
+		jobs:   map[Name]*pollJob{},
+	}
+}
+
+// WithScheduler returns a context that makes providers created with it
+// poll on the given scheduler.
+func WithScheduler(ctx context.Context, scheduler *Scheduler) context.Context {
+	return context.WithValue(ctx, schedulerKey{}, scheduler)
+}
+
+// SchedulerFromContext returns the scheduler of the context, or the
+// default scheduler.
+func SchedulerFromContext(ctx context.Context) *Scheduler {
+	scheduler, ok := ctx.Value(schedulerKey{}).(*Scheduler)
+	if !ok || scheduler == nil {
+		return defaultScheduler
+	}
+	return scheduler
+}
+
+// Add starts the poll loop of a provider, replacing the loop of a previous
+// provider of the same name. The loop polls immediately and then every
+// interval, with jitter, until ctx is done.
+func (s *Scheduler) Add(
+	ctx context.Context,
+	name Name,
+	poller PollingProvider,
+	interval time.Duration,
+	logger zerolog.Logger,
+) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.stopped {
+		logger.Warn().Msg("scheduler is stopped, not polling")
+		return
+	}
+
+	if previous, found := s.jobs[name]; found {
+		previous.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	job := &pollJob{
+		name:     name,
+		poller:   poller,
+		interval: interval,
+		logger:   logger,
+		cancel:   cancel,
+		wake:     make(chan struct{}, 1),
+		status:   PollStatus{Interval: interval},
+	}
+	s.jobs[name] = job
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx, job)
+
+		s.mtx.Lock()
+		if s.jobs[name] == job {
+			delete(s.jobs, name)
+		}
+		s.mtx.Unlock()
+	}()
+}
+
+// Remove stops the poll loop of a provider.
+func (s *Scheduler) Remove(name Name) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	job, found := s.jobs[name]
+	if !found {
+		return
+	}
+	job.cancel()
+	delete(s.jobs, name)
+}
+
+// Pause stops polling a provider until it is resumed, returns false for
+// unknown providers.
+func (s *Scheduler) Pause(name Name) bool {
+	job := s.job(name)
+	if job == nil {
+		return false
+	}
+
+	job.mtx.Lock()
+	job.status.Paused = true
+	job.mtx.Unlock()
+
+	return true
+}
+
+// Resume polls a paused or quarantined provider immediately and
+// continues its loop, returns false for unknown providers.
+func (s *Scheduler) Resume(name Name) bool {
+	job := s.job(name)
+	if job == nil {
+		return false
+	}
+
+	job.mtx.Lock()
+	paused := job.status.Paused || job.status.Quarantined
+	job.status.Paused = false
+	if job.status.Quarantined {
+		job.status.Quarantined = false
+		job.status.Panics = 0
+		telemetryProviderQuarantined(name, false)
+	}
+	job.mtx.Unlock()
+
+	if paused {
+		select {
+		case job.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return true
+}
+
+// Status returns the poll status of all providers.
+func (s *Scheduler) Status() map[Name]PollStatus {
+	// the pollers lock themselves to report the clock skew, so the jobs
+	// are copied before reading it
+	s.mtx.RLock()
+	jobs := make(map[Name]*pollJob, len(s.jobs))
+	for name, job := range s.jobs {
+		jobs[name] = job
+	}
+	s.mtx.RUnlock()
+
+	status := make(map[Name]PollStatus, len(jobs))
+	for name, job := range jobs {
+		job.mtx.RLock()
+		jobStatus := job.status
+		job.mtx.RUnlock()
+
+		if skewed, ok := job.poller.(interface{ ClockSkew() time.Duration }); ok {
+			jobStatus.ClockSkew = skewed.ClockSkew()
+		}
+		status[name] = jobStatus
+	}
+	return status
+}
+
+// Quarantined returns true if the provider is quarantined after repeated
+// panics.
+func (s *Scheduler) Quarantined(name Name) bool {
+	job := s.job(name)
+	if job == nil {
+		return false
+	}
+
+	job.mtx.RLock()
+	defer job.mtx.RUnlock()
+
+	return job.status.Quarantined
+}
+
+// Stop stops all poll loops and waits for running polls to return. No
+// loops can be added afterwards.
+func (s *Scheduler) Stop() {
+	s.mtx.Lock()
+	s.stopped = true
+	for name, job := range s.jobs {
+		job.cancel()
+		delete(s.jobs, name)
+	}
+	s.mtx.Unlock()
+
+	s.wg.Wait()
+	s.logger.Debug().Msg("stopped all poll loops")
+}
+
+func (s *Scheduler) job(name Name) *pollJob {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.jobs[name]
+}
+
+func (s *Scheduler) run(ctx context.Context, job *pollJob) {
+	job.logger.Debug().Dur("interval", job.interval).Msg("starting poll loop")
+	defer job.logger.Debug().Msg("stopped poll loop")
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-job.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		job.mtx.RLock()
+		paused := job.status.Paused || job.status.Quarantined
+		job.mtx.RUnlock()
+
+		wait := s.jittered(job.interval)
+		if !paused {
+			err := safeCall(job.name, componentPoll, job.logger, job.poll)
+			if err != nil {
+				if backoff := job.panicked(err); backoff > wait {
+					wait = backoff
+				}
+			}
+		}
+
+		timer.Reset(wait)
+	}
+}
+
+// jittered returns the interval randomly changed by up to the jitter.
+func (s *Scheduler) jittered(interval time.Duration) time.Duration {
+	if s.jitter <= 0 || interval <= 0 {
+		return interval
+	}
+	factor := 1 + s.jitter*(2*rand.Float64()-1) //nolint:gosec
+	return time.Duration(float64(interval) * factor)
+}
+
+// poll runs a single poll of the provider, unless its websocket is
+// streaming or all its endpoints are rate limited, and records the
+// outcome.
+func (job *pollJob) poll() {
+	p := job.poller
+
+	streaming, ok := p.(interface{ isStreaming() bool })
+	if ok && streaming.isStreaming() {
+		return
+	}
+
+	throttled, ok := p.(interface{ skipPoll() bool })
+	if ok && throttled.skipPoll() {
+		job.logger.Debug().Msg("skipping poll during rate limit cooldown")
+		return
+	}
+
+	var err error
+	trades, ok := p.(interface {
+		isTradeMode() bool
+		pollTrades()
+	})
+	if ok && trades.isTradeMode() {
+		trades.pollTrades()
+	} else {
+		err = p.Poll()
+		if err != nil {
+			job.logger.Error().Err(err).Msg("failed to poll")
+		}
+	}
+
+	books, ok := p.(interface{ pollOrderBooks() })
+	if ok {
+		books.pollOrderBooks()
+	}
+
+	now := time.Now()
+
+	job.mtx.Lock()
+	job.status.LastPoll = now
+	job.status.Panics = 0
+	if err != nil {
+		job.status.LastError = now
+		job.status.Error = err.Error()
+	} else {
+		job.status.LastSuccess = now
+	}
+	job.mtx.Unlock()
+}
+
+// panicked records a recovered panic and returns the backoff before the
+// next poll. The provider is quarantined after panicQuarantineCount
+// consecutive panics.
+func (job *pollJob) panicked(err error) time.Duration {
+	now := time.Now()
+
+	job.mtx.Lock()
+	defer job.mtx.Unlock()
+
+	job.status.Panics++
+	job.status.LastPoll = now
+	job.status.LastError = now
+	job.status.LastPanic = now
+	job.status.Error = err.Error()
+
+	if job.status.Panics >= panicQuarantineCount {
+		job.status.Quarantined = true
+		job.logger.Error().
+			Int("panics", job.status.Panics).
+			Msg("quarantined provider after repeated panics")
+		telemetryProviderQuarantined(job.name, true)
+	}
+
+	return panicBackoff(job.status.Panics)
+}
+
+// startPolling polls the provider on the scheduler of its context, every
+// PollInterval of its endpoints.
+func (p *provider) startPolling(poller PollingProvider) {
+	SchedulerFromContext(p.ctx).Add(
+		p.ctx,
+		p.endpoints.Name,
+		poller,
+		p.endpoints.PollInterval,
+		p.logger,
+	)
+}
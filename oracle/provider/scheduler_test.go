@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type countingPoller struct {
+	polls atomic.Int64
+	err   error
+}
+
+func (p *countingPoller) Poll() error {
+	p.polls.Add(1)
+	return p.err
+}
+
+func TestScheduler(t *testing.T) {
+	scheduler := NewScheduler(zerolog.Nop())
+	defer scheduler.Stop()
+
+	poller := &countingPoller{}
+	scheduler.Add(context.Background(), "test", poller, 10*time.Millisecond, zerolog.Nop())
+
+	require.Eventually(t, func() bool { return poller.polls.Load() >= 3 }, time.Second, time.Millisecond)
+
+	status := scheduler.Status()["test"]
+	require.False(t, status.LastSuccess.IsZero())
+	require.True(t, status.LastError.IsZero())
+
+	// paused providers are not polled until resumed
+	require.True(t, scheduler.Pause("test"))
+	time.Sleep(20 * time.Millisecond)
+	polls := poller.polls.Load()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, polls, poller.polls.Load())
+	require.True(t, scheduler.Status()["test"].Paused)
+
+	require.True(t, scheduler.Resume("test"))
+	require.Eventually(t, func() bool { return poller.polls.Load() > polls }, time.Second, time.Millisecond)
+
+	require.False(t, scheduler.Pause("unknown"))
+
+	// failed polls are reported
+	failing := &countingPoller{err: fmt.Errorf("failed")}
+	scheduler.Add(context.Background(), "failing", failing, 10*time.Millisecond, zerolog.Nop())
+	require.Eventually(t, func() bool { return failing.polls.Load() >= 1 }, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool {
+		return !scheduler.Status()["failing"].LastError.IsZero()
+	}, time.Second, time.Millisecond)
+	require.Equal(t, "failed", scheduler.Status()["failing"].Error)
+
+	// stopping waits for all loops
+	scheduler.Stop()
+	polls = poller.polls.Load()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, polls, poller.polls.Load())
+	require.Empty(t, scheduler.Status())
+}
+
+func TestScheduler_Context(t *testing.T) {
+	scheduler := NewScheduler(zerolog.Nop())
+	defer scheduler.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	poller := &countingPoller{}
+	scheduler.Add(ctx, "test", poller, 10*time.Millisecond, zerolog.Nop())
+	require.Eventually(t, func() bool { return poller.polls.Load() >= 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.Eventually(t, func() bool { return len(scheduler.Status()) == 0 }, time.Second, time.Millisecond)
+
+	require.Equal(t, scheduler, SchedulerFromContext(WithScheduler(context.Background(), scheduler)))
+	require.Equal(t, defaultScheduler, SchedulerFromContext(context.Background()))
+}
+
+func TestScheduler_Jitter(t *testing.T) {
+	scheduler := NewScheduler(zerolog.Nop())
+	for i := 0; i < 100; i++ {
+		interval := scheduler.jittered(time.Second)
+		require.GreaterOrEqual(t, interval, 900*time.Millisecond)
+		require.LessOrEqual(t, interval, 1100*time.Millisecond)
+	}
+}
@@ -73,6 +73,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderShade,
+			Defaults: shadeDefaultEndpoints,
+			New:      withoutDb(NewShadeProvider),
+		},
+	)
+}
+
 func NewShadeProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -96,7 +106,7 @@ func NewShadeProvider(
 
 	provider.init()
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
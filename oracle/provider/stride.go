@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// redemption rates are updated every stride epoch (6h)
+	strideEpoch         = "stride_epoch"
+	strideDefaultMaxAge = 12 * time.Hour
+)
+
+var (
+	_                      Provider = (*StrideProvider)(nil)
+	strideDefaultEndpoints          = Endpoint{
+		Name:         ProviderStride,
+		Urls:         []string{"https://stride-api.polkachu.com"},
+		PollInterval: 30 * time.Second,
+	}
+)
+
+type (
+	// StrideProvider defines an oracle provider using the redemption rates
+	// of stride liquid staking tokens in their underlying token, ex.:
+	// STATOMATOM, which are converted to usd with the price of the underlying
+	// token. The host zones are configured by their chain id in the contract
+	// addresses, ex.: STATOMATOM = "cosmoshub-4". The maximum age of a
+	// redemption rate is set by the max ages of the endpoint.
+	//
+	// REF: https://github.com/Stride-Labs/stride/tree/main/x/stakeibc
+	StrideProvider struct {
+		provider
+	}
+
+	StrideHostZonesResponse struct {
+		HostZones []StrideHostZone `json:"host_zone"`
+	}
+
+	StrideHostZone struct {
+		ChainId        string `json:"chain_id"`
+		HostDenom      string `json:"host_denom"`
+		RedemptionRate string `json:"redemption_rate"`
+		Halted         bool   `json:"halted"`
+	}
+
+	StrideEpochTrackerResponse struct {
+		EpochTracker StrideEpochTracker `json:"epoch_tracker"`
+	}
+
+	StrideEpochTracker struct {
+		Identifier         string `json:"epoch_identifier"`
+		Number             string `json:"epoch_number"`
+		NextEpochStartTime string `json:"next_epoch_start_time"` // unix nanoseconds
+		Duration           string `json:"duration"`              // nanoseconds
+	}
+)
+
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderStride,
+			Defaults: strideDefaultEndpoints,
+			New:      withoutDb(NewStrideProvider),
+		},
+	)
+}
+
+func NewStrideProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*StrideProvider, error) {
+	provider := &StrideProvider{}
+	provider.Init(
+		ctx,
+		endpoints,
+		logger,
+		pairs,
+		nil,
+		nil,
+	)
+
+	availablePairs, _ := provider.GetAvailablePairs()
+	provider.setPairs(pairs, availablePairs, nil)
+
+	provider.startPolling(provider)
+	return provider, nil
+}
+
+func (p *StrideProvider) Poll() error {
+	updated, err := p.getLastUpdate()
+	if err != nil {
+		return err
+	}
+
+	content, err := p.httpGet("/Stride-Labs/stride/stakeibc/host_zone")
+	if err != nil {
+		return err
+	}
+
+	var response StrideHostZonesResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return p.error(err)
+	}
+
+	hostZones := map[string]StrideHostZone{}
+	for _, hostZone := range response.HostZones {
+		hostZones[hostZone.ChainId] = hostZone
+	}
+
+	timestamp := time.Now()
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for symbol := range p.getAllPairs() {
+		chainId, found := p.contracts[symbol]
+		if !found {
+			p.logger.Warn().Str("symbol", symbol).Msg("no host zone found")
+			continue
+		}
+
+		hostZone, found := hostZones[chainId]
+		if !found {
+			p.logger.Warn().
+				Str("symbol", symbol).
+				Str("chain_id", chainId).
+				Msg("host zone not found")
+			continue
+		}
+
+		if hostZone.Halted {
+			p.logger.Warn().
+				Str("symbol", symbol).
+				Str("chain_id", chainId).
+				Msg("host zone is halted")
+			continue
+		}
+
+		maxAge := p.maxAge(symbol)
+		if timestamp.Sub(updated) > maxAge {
+			p.logger.Warn().
+				Str("symbol", symbol).
+				Time("updated", updated).
+				Dur("max_age", maxAge).
+				Msg("redemption rate is stale")
+			continue
+		}
+
+		rate := strToDec(hostZone.RedemptionRate)
+		if rate.IsNil() {
+			p.logger.Error().
+				Str("symbol", symbol).
+				Msg("failed parsing redemption rate")
+			continue
+		}
+
+		p.setTickerPrice(
+			symbol,
+			rate,
+			math.LegacyZeroDec(),
+			timestamp,
+		)
+	}
+
+	p.logger.Debug().Msg("updated tickers")
+	return nil
+}
+
+// getLastUpdate returns the start time of the current stride epoch, at which
+// the redemption rates of all host zones were last updated.
+func (p *StrideProvider) getLastUpdate() (time.Time, error) {
+	content, err := p.httpGet("/Stride-Labs/stride/stakeibc/epoch_tracker/" + strideEpoch)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var response StrideEpochTrackerResponse
+	err = json.Unmarshal(content, &response)
+	if err != nil {
+		return time.Time{}, p.error(err)
+	}
+
+	next, err := strconv.ParseInt(response.EpochTracker.NextEpochStartTime, 10, 64)
+	if err != nil {
+		return time.Time{}, p.error(err)
+	}
+
+	duration, err := strconv.ParseInt(response.EpochTracker.Duration, 10, 64)
+	if err != nil {
+		return time.Time{}, p.error(err)
+	}
+
+	return time.Unix(0, next-duration), nil
+}
+
+func (p *StrideProvider) maxAge(symbol string) time.Duration {
+	maxAge, found := p.endpoints.MaxAges[symbol]
+	if !found || maxAge <= 0 {
+		return strideDefaultMaxAge
+	}
+	return maxAge
+}
+
+func (p *StrideProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	return p.getAvailablePairsFromContracts()
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrideProvider_Poll(t *testing.T) {
+	epoch := 6 * time.Hour
+	epochStart := time.Now().Add(-time.Hour)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/Stride-Labs/stride/stakeibc/epoch_tracker/stride_epoch":
+			fmt.Fprintf(rw,
+				`{"epoch_tracker":{"epoch_identifier":"stride_epoch","epoch_number":"100",`+
+					`"next_epoch_start_time":"%d","duration":"%d"}}`,
+				epochStart.Add(epoch).UnixNano(), epoch.Nanoseconds(),
+			)
+		case "/Stride-Labs/stride/stakeibc/host_zone":
+			fmt.Fprint(rw, `{"host_zone":[`+
+				`{"chain_id":"cosmoshub-4","host_denom":"uatom","redemption_rate":"1.250000000000000000","halted":false},`+
+				`{"chain_id":"osmosis-1","host_denom":"uosmo","redemption_rate":"1.100000000000000000","halted":true}`+
+				`]}`)
+		default:
+			http.NotFound(rw, req)
+		}
+	}))
+	defer server.Close()
+
+	statomAtom := types.CurrencyPair{Base: "STATOM", Quote: "ATOM"}
+	stosmoOsmo := types.CurrencyPair{Base: "STOSMO", Quote: "OSMO"}
+
+	endpoints := Endpoint{
+		Name:         ProviderStride,
+		Urls:         []string{server.URL},
+		PollInterval: 50 * time.Millisecond,
+		ContractAddresses: map[string]string{
+			"STATOMATOM": "cosmoshub-4",
+			"STOSMOOSMO": "osmosis-1",
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, err := NewStrideProvider(ctx, zerolog.Nop(), endpoints, statomAtom, stosmoOsmo)
+	require.NoError(t, err)
+
+	var prices map[string]types.TickerPrice
+	require.Eventually(t, func() bool {
+		prices, err = p.GetTickerPrices(statomAtom, stosmoOsmo)
+		return err == nil && len(prices) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// halted host zones are skipped
+	require.Len(t, prices, 1)
+	require.Equal(t, math.LegacyMustNewDecFromStr("1.25"), prices["STATOMATOM"].Price)
+
+	// rates older than the max age are stale
+	// the urls are copied, Init trims them while the first provider polls
+	endpoints.Urls = []string{server.URL}
+	endpoints.MaxAges = map[string]time.Duration{"STATOMATOM": time.Minute}
+	p, err = NewStrideProvider(ctx, zerolog.Nop(), endpoints, statomAtom)
+	require.NoError(t, err)
+
+	require.Never(t, func() bool {
+		prices, err = p.GetTickerPrices(statomAtom)
+		return err == nil && len(prices) > 0
+	}, 300*time.Millisecond, 50*time.Millisecond)
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	panicBackoffMin = time.Second
+	panicBackoffMax = 5 * time.Minute
+	// consecutive panics after which a poll loop or websocket is quarantined
+	panicQuarantineCount = 5
+
+	componentPoll      = "poll"
+	componentWebsocket = "websocket"
+)
+
+// safeCall runs fn and recovers a panic, which is logged with its stack
+// trace and counted in the provider_panics metric. Returns the recovered
+// panic as error.
+func safeCall(name Name, component string, logger zerolog.Logger, fn func()) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		err = fmt.Errorf("panic: %v", r)
+		logger.Error().
+			Str("component", component).
+			Str("stack", string(debug.Stack())).
+			Msgf("recovered %s", err)
+		telemetryProviderPanic(name, component)
+	}()
+
+	fn()
+	return nil
+}
+
+// panicBackoff returns the delay before restarting after the given number
+// of consecutive panics, doubling from panicBackoffMin up to
+// panicBackoffMax.
+func panicBackoff(panics int) time.Duration {
+	backoff := panicBackoffMin
+	for i := 1; i < panics; i++ {
+		backoff *= 2
+		if backoff >= panicBackoffMax {
+			return panicBackoffMax
+		}
+	}
+	return backoff
+}
+
+// locked runs fn with the provider lock held. The lock is released even if
+// fn panics, so that a recovered panic doesn't leave the provider locked.
+func (p *provider) locked(fn func()) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	fn()
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+type panickingPoller struct{}
+
+func (panickingPoller) Poll() error {
+	var tickers map[string]int
+	tickers["ATOMUSDT"] = 1 // nil map
+	return nil
+}
+
+func TestSafeCall(t *testing.T) {
+	err := safeCall("test", componentPoll, zerolog.Nop(), func() {
+		var tickers []int
+		_ = tickers[1]
+	})
+	require.ErrorContains(t, err, "index out of range")
+
+	err = safeCall("test", componentPoll, zerolog.Nop(), func() {})
+	require.NoError(t, err)
+}
+
+func TestPanicBackoff(t *testing.T) {
+	require.Equal(t, time.Second, panicBackoff(1))
+	require.Equal(t, 4*time.Second, panicBackoff(3))
+	require.Equal(t, panicBackoffMax, panicBackoff(20))
+}
+
+func TestScheduler_Quarantine(t *testing.T) {
+	scheduler := NewScheduler(zerolog.Nop())
+	defer scheduler.Stop()
+
+	scheduler.Add(context.Background(), "test", panickingPoller{}, 10*time.Millisecond, zerolog.Nop())
+
+	// the panic is recovered and the poll retried after a backoff
+	require.Eventually(t, func() bool {
+		return scheduler.Status()["test"].Panics == 1
+	}, time.Second, time.Millisecond)
+	require.False(t, scheduler.Quarantined("test"))
+
+	job := scheduler.job("test")
+	for i := 1; i < panicQuarantineCount; i++ {
+		job.panicked(fmt.Errorf("panic"))
+	}
+	require.True(t, scheduler.Quarantined("test"))
+	require.True(t, scheduler.Status()["test"].Quarantined)
+
+	require.True(t, scheduler.Resume("test"))
+	require.False(t, scheduler.Quarantined("test"))
+}
+
+func TestSafeCall_ReleasesLock(t *testing.T) {
+	p := newTestWebsocketProvider("ATOMUSDT")
+
+	// a nil volume panics while the lock is held
+	err := safeCall("test", componentPoll, zerolog.Nop(), func() {
+		p.locked(func() {
+			p.setTickerPrice("ATOMUSDT", testAtomPriceDec, math.LegacyDec{}, time.Now())
+		})
+	})
+	require.Error(t, err)
+
+	require.True(t, p.mtx.TryLock())
+	p.mtx.Unlock()
+}
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	"github.com/hashicorp/go-metrics"
 )
@@ -111,6 +113,34 @@ func TelemetryProviderPrice(name Name, denom string, price float32, volume float
 	telemetry.SetGaugeWithLabels([]string{"provider", "volume"}, volume, labels)
 }
 
+// TelemetryProviderLiquidity gives an standard way to add
+// `price_feeder_provider_liquidity{provider="x", denom="x"}` metric.
+func TelemetryProviderLiquidity(name Name, denom string, liquidity float32) {
+	labels := []metrics.Label{
+		providerLabel(name),
+		{
+			Name:  "denom",
+			Value: denom,
+		},
+	}
+
+	telemetry.SetGaugeWithLabels([]string{"provider", "liquidity"}, liquidity, labels)
+}
+
+// TelemetryProviderSpread gives an standard way to add
+// `price_feeder_provider_spread{provider="x", denom="x"}` metric.
+func TelemetryProviderSpread(name Name, denom string, spread float32) {
+	labels := []metrics.Label{
+		providerLabel(name),
+		{
+			Name:  "denom",
+			Value: denom,
+		},
+	}
+
+	telemetry.SetGaugeWithLabels([]string{"provider", "spread"}, spread, labels)
+}
+
 func TelemetryEvmMethod(chain, provider, method string) {
 	labels := []metrics.Label{
 		{
@@ -135,3 +165,125 @@ func TelemetryEvmMethod(chain, provider, method string) {
 		labels,
 	)
 }
+
+// telemetryHttpThrottled gives an standard way to add
+// `price_feeder_http_throttled{reason="x", provider="x"}` metric.
+func telemetryHttpThrottled(n Name, reason string) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"http",
+			"throttled",
+		},
+		1,
+		[]metrics.Label{
+			providerLabel(n),
+			{
+				Name:  "reason",
+				Value: reason,
+			},
+		},
+	)
+}
+
+// telemetryEndpointHealth gives an standard way to set the
+// `price_feeder_endpoint_{latency_ms,error_rate,lagging}{provider="x", url="x"}`
+// metrics.
+func telemetryEndpointHealth(n Name, url string, score urlScore) {
+	labels := []metrics.Label{
+		providerLabel(n),
+		{
+			Name:  "url",
+			Value: url,
+		},
+	}
+
+	lagging := float32(0)
+	if score.lagging {
+		lagging = 1
+	}
+
+	telemetry.SetGaugeWithLabels([]string{"endpoint", "latency_ms"}, float32(score.latency.Milliseconds()), labels)
+	telemetry.SetGaugeWithLabels([]string{"endpoint", "error_rate"}, float32(score.errorRate), labels)
+	telemetry.SetGaugeWithLabels([]string{"endpoint", "lagging"}, lagging, labels)
+}
+
+// telemetryProviderPanic gives an standard way to add
+// `price_feeder_provider_panics{provider="x", component="x"}` metric.
+func telemetryProviderPanic(n Name, component string) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"provider",
+			"panics",
+		},
+		1,
+		[]metrics.Label{
+			providerLabel(n),
+			{
+				Name:  "component",
+				Value: component,
+			},
+		},
+	)
+}
+
+// telemetryProviderQuarantined gives an standard way to set the
+// `price_feeder_provider_quarantined{provider="x"}` metric.
+func telemetryProviderQuarantined(n Name, quarantined bool) {
+	value := float32(0)
+	if quarantined {
+		value = 1
+	}
+
+	telemetry.SetGaugeWithLabels(
+		[]string{
+			"provider",
+			"quarantined",
+		},
+		value,
+		[]metrics.Label{providerLabel(n)},
+	)
+}
+
+// TelemetryProviderInitFailure gives an standard way to add
+// `price_feeder_provider_init_failures{provider="x"}` metric.
+func TelemetryProviderInitFailure(n Name) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"provider",
+			"init_failures",
+		},
+		1,
+		[]metrics.Label{providerLabel(n)},
+	)
+}
+
+// TelemetryProviderUnavailable gives an standard way to set the
+// `price_feeder_provider_unavailable{provider="x"}` metric.
+func TelemetryProviderUnavailable(n Name, unavailable bool) {
+	value := float32(0)
+	if unavailable {
+		value = 1
+	}
+
+	telemetry.SetGaugeWithLabels(
+		[]string{
+			"provider",
+			"unavailable",
+		},
+		value,
+		[]metrics.Label{providerLabel(n)},
+	)
+}
+
+// telemetryProviderClockSkew gives an standard way to set the
+// `price_feeder_provider_clock_skew_ms{provider="x"}` metric.
+func telemetryProviderClockSkew(n Name, skew time.Duration) {
+	telemetry.SetGaugeWithLabels(
+		[]string{
+			"provider",
+			"clock_skew_ms",
+		},
+		float32(skew.Milliseconds()),
+		[]metrics.Label{providerLabel(n)},
+	)
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"cosmossdk.io/math"
+)
+
+const defaultTradeWindow = 5 * time.Minute
+
+type (
+	// TradeOptions enables the trade mode of a provider. Prices are the
+	// volume weighted average price of all trades within the rolling
+	// window and volumes are the summed trade amounts in base.
+	TradeOptions struct {
+		Window time.Duration
+	}
+
+	Trade struct {
+		ID     string
+		Price  math.LegacyDec
+		Amount math.LegacyDec
+		Time   time.Time
+	}
+
+	// TradeHandler returns the recent trades of the provider symbol.
+	TradeHandler func(symbol string) ([]Trade, error)
+)
+
+// key identifies a trade within the window, trades without an id are
+// identified by their content.
+func (t Trade) key() string {
+	if t.ID != "" {
+		return t.ID
+	}
+	return fmt.Sprintf("%d:%s:%s", t.Time.UnixNano(), t.Price, t.Amount)
+}
+
+// setTradeHandler sets the handler to poll trades in trade mode, stream
+// defines whether the websocket of the provider subscribes to trades in
+// trade mode.
+func (p *provider) setTradeHandler(handler TradeHandler, stream bool) {
+	p.tradeHandler = handler
+	p.tradeStream = stream
+}
+
+func (p *provider) isTradeMode() bool {
+	return p.endpoints.Trades != nil && p.tradeHandler != nil
+}
+
+func (p *provider) tradeWindow() time.Duration {
+	if p.endpoints.Trades == nil || p.endpoints.Trades.Window <= 0 {
+		return defaultTradeWindow
+	}
+	return p.endpoints.Trades.Window
+}
+
+// pollTrades fetches the recent trades of all pairs.
+func (p *provider) pollTrades() {
+	p.mtx.RLock()
+	pairs := p.getAllPairs()
+	p.mtx.RUnlock()
+
+	for symbol := range pairs {
+		trades, err := p.tradeHandler(symbol)
+		if err != nil {
+			p.logger.Warn().Err(err).Str("symbol", symbol).Msg("failed to get trades")
+			continue
+		}
+
+		p.locked(func() {
+			p.setTrades(symbol, trades...)
+		})
+	}
+
+	p.logger.Debug().Msg("updated trades")
+}
+
+// setTrades adds the trades to the window of the provider symbol, drops
+// expired trades and sets the ticker to the VWAP and volume of the window.
+func (p *provider) setTrades(symbol string, trades ...Trade) {
+	if p.trades == nil {
+		p.trades = map[string]map[string]Trade{}
+	}
+
+	window, found := p.trades[symbol]
+	if !found {
+		window = map[string]Trade{}
+		p.trades[symbol] = window
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-p.tradeWindow())
+
+	for _, trade := range trades {
+		if trade.Price.IsNil() || !trade.Price.IsPositive() ||
+			trade.Amount.IsNil() || !trade.Amount.IsPositive() ||
+			trade.Time.Before(cutoff) {
+			continue
+		}
+		window[trade.key()] = trade
+	}
+
+	value := math.LegacyZeroDec()
+	volume := math.LegacyZeroDec()
+	for key, trade := range window {
+		if trade.Time.Before(cutoff) {
+			delete(window, key)
+			continue
+		}
+		value = value.Add(trade.Price.Mul(trade.Amount))
+		volume = volume.Add(trade.Amount)
+	}
+
+	if !volume.IsPositive() {
+		return
+	}
+
+	p.setTickerPrice(symbol, value.Quo(volume), volume, now)
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_setTrades(t *testing.T) {
+	p := newTestWebsocketProvider("ATOMUSDT")
+	p.endpoints = Endpoint{
+		Name:   ProviderBinance,
+		Trades: &TradeOptions{Window: time.Minute},
+	}
+
+	now := time.Now()
+
+	p.setTrades("ATOMUSDT",
+		Trade{ID: "1", Price: math.LegacyNewDec(10), Amount: math.LegacyNewDec(1), Time: now},
+		Trade{ID: "2", Price: math.LegacyNewDec(13), Amount: math.LegacyNewDec(2), Time: now},
+		// expired
+		Trade{ID: "3", Price: math.LegacyNewDec(100), Amount: math.LegacyNewDec(1), Time: now.Add(-2 * time.Minute)},
+	)
+
+	ticker := p.tickers["ATOMUSDT"]
+	require.Equal(t, math.LegacyNewDec(12), ticker.Price)
+	require.Equal(t, math.LegacyNewDec(3), ticker.Volume)
+
+	// duplicates of polled trades are ignored
+	p.setTrades("ATOMUSDT",
+		Trade{ID: "2", Price: math.LegacyNewDec(13), Amount: math.LegacyNewDec(2), Time: now},
+		Trade{ID: "4", Price: math.LegacyNewDec(4), Amount: math.LegacyNewDec(1), Time: now},
+	)
+
+	ticker = p.tickers["ATOMUSDT"]
+	require.Equal(t, math.LegacyNewDec(10), ticker.Price)
+	require.Equal(t, math.LegacyNewDec(4), ticker.Volume)
+}
+
+func TestProviders_websocketTradeReceived(t *testing.T) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	testCases := []struct {
+		name    string
+		handler func() (MessageHandler, *provider)
+		message string
+	}{
+		{
+			"binance",
+			func() (MessageHandler, *provider) {
+				p := &BinanceProvider{provider: newTestWebsocketProvider("ATOMUSDT")}
+				return p.messageReceived, &p.provider
+			},
+			`{"e":"trade","E":` + now + `,"s":"ATOMUSDT","t":12345,"p":"12.3456","q":"100","T":` + now + `,"m":true,"M":true}`,
+		},
+		{
+			"bybit",
+			func() (MessageHandler, *provider) {
+				p := &BybitProvider{provider: newTestWebsocketProvider("ATOMUSDT")}
+				return p.messageReceived, &p.provider
+			},
+			`{"topic":"publicTrade.ATOMUSDT","type":"snapshot","data":[{"T":` + now + `,"s":"ATOMUSDT","S":"Buy","v":"100","p":"12.3456","i":"2290000000061666327"}]}`,
+		},
+		{
+			"okx",
+			func() (MessageHandler, *provider) {
+				p := &OkxProvider{provider: newTestWebsocketProvider("ATOM-USDT")}
+				return p.messageReceived, &p.provider
+			},
+			`{"arg":{"channel":"trades","instId":"ATOM-USDT"},"data":[{"instId":"ATOM-USDT","tradeId":"130639474","px":"12.3456","sz":"100","side":"buy","ts":"` + now + `"}]}`,
+		},
+		{
+			"coinbase",
+			func() (MessageHandler, *provider) {
+				p := &CoinbaseProvider{provider: newTestWebsocketProvider("ATOM-USDT")}
+				return p.messageReceived, &p.provider
+			},
+			`{"type":"match","trade_id":10,"product_id":"ATOM-USDT","size":"100","price":"12.3456","side":"sell","time":"` +
+				time.Now().UTC().Format(time.RFC3339Nano) + `"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, p := tc.handler()
+			p.endpoints.Trades = &TradeOptions{}
+
+			handler(websocket.TextMessage, []byte(tc.message))
+			require.Len(t, p.tickers, 1)
+
+			ticker := p.tickers["ATOMUSDT"]
+			require.Equal(t, testAtomPriceDec, ticker.Price)
+			require.Equal(t, math.LegacyNewDec(100), ticker.Volume)
+		})
+	}
+}
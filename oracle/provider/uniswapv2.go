@@ -0,0 +1,323 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+)
+
+var (
+	_                         Provider = (*UniswapV2Provider)(nil)
+	uniswapv2DefaultEndpoints          = Endpoint{
+		Name:         ProviderUniswapV2,
+		Urls:         []string{},
+		PollInterval: 15 * time.Second,
+		VolumeBlocks: 1,
+		VolumePause:  0,
+	}
+)
+
+type (
+	// UniswapV2Provider defines an oracle provider calling uniswap v2 style
+	// pair contracts on any evm chain, e.g. SushiSwap or QuickSwap. The
+	// contract addresses are keyed by token0 and token1 of the pair, ex.:
+	// WETHUSDC = "0x...".
+	UniswapV2Provider struct {
+		provider
+		topic    string
+		decimals map[string][2]uint64
+	}
+)
+
+func init() {
+	Register(
+		Registration{
+			Name:          ProviderUniswapV2,
+			Defaults:      uniswapv2DefaultEndpoints,
+			New:           withDb(NewUniswapV2Provider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+	)
+}
+
+func NewUniswapV2Provider(
+	db *sql.DB,
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*UniswapV2Provider, error) {
+	provider := &UniswapV2Provider{}
+	provider.db = db
+	provider.Init(
+		ctx,
+		endpoints,
+		logger,
+		pairs,
+		nil,
+		nil,
+	)
+
+	provider.chain = provider.endpoints.Chain
+	provider.name = provider.endpoints.Name.String()
+
+	topic, err := keccak256("Swap(address,uint256,uint256,uint256,uint256,address)")
+	if err != nil {
+		return nil, err
+	}
+	provider.topic = "0x" + topic
+
+	availablePairs, _ := provider.GetAvailablePairs()
+	provider.setPairs(pairs, availablePairs, nil)
+
+	provider.setDecimals()
+
+	provider.startPolling(provider)
+	return provider, nil
+}
+
+func (p *UniswapV2Provider) GetAvailablePairs() (map[string]struct{}, error) {
+	return p.getAvailablePairsFromContracts()
+}
+
+func (p *UniswapV2Provider) Poll() error {
+	p.mtx.RLock()
+	pairs := p.getAllPairs()
+	p.mtx.RUnlock()
+
+	contracts := []string{}
+	for symbol := range pairs {
+		contract, found := p.contracts[symbol]
+		if !found {
+			continue
+		}
+		contracts = append(contracts, contract)
+	}
+
+	err := p.updateEvmVolumes(func(from, to uint64) error {
+		return p.updateVolumes(from, to, contracts)
+	})
+	if err != nil {
+		p.logger.Warn().Err(err).Msg("failed to update volumes")
+	}
+
+	timestamp, err := p.getEvmBlockTime()
+	if err != nil {
+		p.logger.Warn().Err(err).Msg("failed to get block time")
+		timestamp = time.Now()
+	}
+
+	for _, contract := range contracts {
+		symbol := p.contracts[contract]
+
+		// pair in order of token0 and token1
+		p.mtx.RLock()
+		pair, found := p.getPair(symbol)
+		p.mtx.RUnlock()
+		if !found {
+			continue
+		}
+
+		decimals, found := p.decimals[contract]
+		if !found {
+			p.logger.Warn().Str("symbol", symbol).Msg("decimals not found")
+			continue
+		}
+
+		reserves, err := p.getReserves(contract, decimals)
+		if err != nil {
+			p.logger.Err(err).Str("symbol", symbol).Msg("failed to get reserves")
+			continue
+		}
+
+		if !reserves[0].IsPositive() || !reserves[1].IsPositive() {
+			p.logger.Warn().Str("symbol", symbol).Msg("pool has no reserves")
+			continue
+		}
+
+		price := reserves[1].Quo(reserves[0])
+
+		// volumes are stored in token0 for the pair and in token1 for the
+		// swapped pair
+		volume, _ := p.volumes.Get(pair.String())
+
+		p.locked(func() {
+			p.setTickerPrice(symbol, price, volume, timestamp)
+			p.setTickerLiquidity(symbol, poolLiquidity(reserves[0], reserves[1], price))
+		})
+	}
+
+	p.logger.Debug().Msg("updated tickers")
+	return nil
+}
+
+// getReserves returns the reserves of token0 and token1, adjusted by their
+// decimals.
+func (p *UniswapV2Provider) getReserves(
+	contract string,
+	decimals [2]uint64,
+) ([2]math.LegacyDec, error) {
+	response, err := p.evmCall(contract, "getReserves()", nil)
+	if err != nil {
+		return [2]math.LegacyDec{}, err
+	}
+
+	var data string
+	err = json.Unmarshal(response, &data)
+	if err != nil {
+		return [2]math.LegacyDec{}, p.error(err)
+	}
+
+	decoded, err := decodeEthData(data, []string{"uint112", "uint112", "uint32"})
+	if err != nil {
+		return [2]math.LegacyDec{}, p.error(err)
+	}
+
+	reserves := [2]math.LegacyDec{}
+	for i := range reserves {
+		reserve := strToDec(fmt.Sprintf("%v", decoded[i]))
+		if reserve.IsNil() {
+			return [2]math.LegacyDec{}, p.errorf("failed parsing reserves")
+		}
+		reserves[i] = reserve.Quo(uintToDec(10).Power(decimals[i]))
+	}
+
+	return reserves, nil
+}
+
+// setDecimals gets the decimals of the tokens of all pair contracts.
+func (p *UniswapV2Provider) setDecimals() {
+	p.decimals = map[string][2]uint64{}
+
+	for symbol := range p.getAllPairs() {
+		logger := p.logger.With().Str("symbol", symbol).Logger()
+
+		contract, found := p.contracts[symbol]
+		if !found {
+			logger.Warn().Msg("contract not found")
+			continue
+		}
+
+		tokens := [2]string{}
+		decimals := [2]uint64{}
+		failed := false
+
+		for i, method := range []string{"token0()", "token1()"} {
+			response, err := p.evmCall(contract, method, nil)
+			if err != nil {
+				failed = true
+				break
+			}
+
+			var data string
+			err = json.Unmarshal(response, &data)
+			if err != nil {
+				failed = true
+				break
+			}
+
+			decoded, err := decodeEthData(data, []string{"address"})
+			if err != nil {
+				failed = true
+				break
+			}
+			tokens[i] = fmt.Sprintf("%v", decoded[0])
+
+			decimals[i], err = p.getEthDecimals(tokens[i])
+			if err != nil {
+				failed = true
+				break
+			}
+		}
+
+		if failed {
+			logger.Error().Msg("failed to get tokens")
+			continue
+		}
+
+		p.decimals[contract] = decimals
+	}
+}
+
+func (p *UniswapV2Provider) updateVolumes(
+	height1, height2 uint64,
+	addresses []string,
+) error {
+	if len(p.volumes.Symbols()) == 0 {
+		return nil
+	}
+
+	if height1 >= height2 {
+		return nil
+	}
+
+	volumes, err := p.newEvmVolumes(height1, height2)
+	if err != nil {
+		return err
+	}
+	height1 = height1 + 1
+
+	logs, err := p.evmGetLogs(height1, height2, addresses, []string{p.topic})
+	if err != nil {
+		return err
+	}
+
+	for _, log := range logs {
+		symbol, found := p.contracts[log.Address]
+		if !found {
+			p.logger.Warn().Str("contract", log.Address).Msg("symbol not found")
+			continue
+		}
+
+		p.mtx.RLock()
+		pair, found := p.getPair(symbol)
+		p.mtx.RUnlock()
+		if !found {
+			continue
+		}
+
+		decimals, found := p.decimals[p.contracts[symbol]]
+		if !found {
+			p.logger.Warn().Str("symbol", symbol).Msg("no decimals found")
+			continue
+		}
+
+		// amount0In, amount1In, amount0Out, amount1Out
+		data, err := decodeEthData(log.Data, []string{"uint256", "uint256", "uint256", "uint256"})
+		if err != nil {
+			return p.error(err)
+		}
+
+		index := int(log.Height - height1)
+		if index >= len(volumes) || index < 0 {
+			return p.errorf("log height out of range")
+		}
+
+		ten := int64ToDec(10)
+
+		symbols := []string{pair.String(), pair.Swap().String()}
+		for i, symbol := range symbols {
+			amountIn := strToDec(fmt.Sprintf("%v", data[i]))
+			amountOut := strToDec(fmt.Sprintf("%v", data[i+2]))
+			amount := amountIn.Add(amountOut).Quo(ten.Power(decimals[i]))
+
+			current, found := volumes[index].Values[symbol]
+			if !found {
+				continue
+			}
+			volumes[index].Values[symbol] = current.Add(amount)
+		}
+	}
+
+	p.volumes.Add(volumes)
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniswapV2Provider_Poll(t *testing.T) {
+	pool := "0x905dfcd5649217c42684f23958568e533c711aa3"
+	token0 := fmt.Sprintf("0x%040x", 1)
+	token1 := fmt.Sprintf("0x%040x", 2)
+
+	results := map[string]func(to string) string{}
+	for method, result := range map[string]func(to string) string{
+		"token0()": func(string) string { return fmt.Sprintf("%064s", token0[2:]) },
+		"token1()": func(string) string { return fmt.Sprintf("%064s", token1[2:]) },
+		"decimals()": func(to string) string {
+			if to == token0 {
+				return fmt.Sprintf("%064x", 18)
+			}
+			return fmt.Sprintf("%064x", 6)
+		},
+		// 2 WETH and 6000 USDC
+		"getReserves()": func(string) string {
+			return fmt.Sprintf("%064x%064x%064x", uint64(2e18), uint64(6000e6), 0)
+		},
+	} {
+		hash, err := keccak256(method)
+		require.NoError(t, err)
+		results[hash[:8]] = result
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var request struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+
+		if request.Method == "eth_blockNumber" {
+			fmt.Fprint(rw, `{"jsonrpc":"2.0","id":1,"result":"0x10"}`)
+			return
+		}
+
+		if request.Method == "eth_getBlockByNumber" {
+			fmt.Fprintf(rw, `{"jsonrpc":"2.0","id":1,"result":{"timestamp":"0x%x"}}`, time.Now().Unix())
+			return
+		}
+
+		var call struct {
+			To   string `json:"to"`
+			Data string `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(request.Params[0], &call))
+
+		result, found := results[strings.TrimPrefix(call.Data, "0x")[:8]]
+		require.True(t, found)
+
+		fmt.Fprintf(rw, `{"jsonrpc":"2.0","id":1,"result":"0x%s"}`, result(call.To))
+	}))
+	defer server.Close()
+
+	pair := types.CurrencyPair{Base: "ETH", Quote: "USDC"}
+
+	endpoints := Endpoint{
+		Name:              "sushiswap_arbitrum",
+		Type:              ProviderUniswapV2,
+		Urls:              []string{server.URL},
+		PollInterval:      50 * time.Millisecond,
+		ContractAddresses: map[string]string{"ETHUSDC": pool},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, err := NewUniswapV2Provider(nil, ctx, zerolog.Nop(), endpoints, pair)
+	require.NoError(t, err)
+
+	var prices map[string]types.TickerPrice
+	require.Eventually(t, func() bool {
+		prices, err = p.GetTickerPrices(pair)
+		return err == nil && len(prices) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	ticker := prices["ETHUSDC"]
+	require.Equal(t, math.LegacyNewDec(3000), ticker.Price)
+	require.Equal(t, math.LegacyNewDec(12000), ticker.Liquidity)
+}
@@ -38,6 +38,7 @@ type (
 	UniswapV3Provider struct {
 		provider
 		decimals map[string]uint64
+		tokens   map[string][2]string
 	}
 
 	UniswapV3Response struct {
@@ -45,6 +46,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderUniswapV3,
+			Defaults: uniswapv3DefaultEndpoints,
+			New:      withoutDb(NewUniswapV3Provider),
+		},
+	)
+}
+
 func NewUniswapV3Provider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -67,7 +78,7 @@ func NewUniswapV3Provider(
 	// get token decimals
 	provider.setDecimals()
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -76,6 +87,12 @@ func (p *UniswapV3Provider) Poll() error {
 		"uint160", "int24", "uint16", "uint16", "uint16", "uint8", "bool",
 	}
 
+	timestamp, err := p.getEvmBlockTime()
+	if err != nil {
+		p.logger.Warn().Err(err).Msg("failed to get block time")
+		timestamp = time.Now()
+	}
+
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
@@ -146,14 +163,33 @@ func (p *UniswapV3Provider) Poll() error {
 			price = price.Quo(math.LegacyNewDec(10).Power(diff))
 		}
 
-		now := time.Now()
-
 		p.setTickerPrice(
 			symbol,
 			price,
 			math.LegacyZeroDec(),
-			now,
+			timestamp,
 		)
+
+		tokens, found := p.tokens[contract]
+		if !found {
+			continue
+		}
+
+		liquidity, err := p.getEvmPoolLiquidity(
+			contract,
+			tokens,
+			[2]uint64{decimalsBase, decimalsQuote},
+			price,
+		)
+		if err != nil {
+			p.logger.Warn().
+				Err(err).
+				Str("symbol", symbol).
+				Msg("failed to get pool liquidity")
+			continue
+		}
+
+		p.setTickerLiquidity(symbol, liquidity)
 	}
 
 	return nil
@@ -260,6 +296,7 @@ func (p *UniswapV3Provider) getUniswapV3Decimals(contract string) (uint64, error
 
 func (p *UniswapV3Provider) setDecimals() {
 	p.decimals = map[string]uint64{}
+	p.tokens = map[string][2]string{}
 
 	for _, pair := range p.getAllPairs() {
 		contract, err := p.getContractAddress(pair)
@@ -279,6 +316,7 @@ func (p *UniswapV3Provider) setDecimals() {
 		}
 
 		denoms := []string{base, quote}
+		tokens := [2]string{}
 
 		// get decimals for token0 and token1
 		for i := int64(0); i < 2; i++ {
@@ -288,6 +326,7 @@ func (p *UniswapV3Provider) setDecimals() {
 				continue
 			}
 
+			tokens[i] = tokenAddress
 			denom := denoms[i]
 
 			_, found = p.decimals[denom]
@@ -303,5 +342,9 @@ func (p *UniswapV3Provider) setDecimals() {
 				p.decimals[denom] = decimals
 			}
 		}
+
+		if tokens[0] != "" && tokens[1] != "" {
+			p.tokens[contract] = tokens
+		}
 	}
 }
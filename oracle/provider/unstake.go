@@ -31,6 +31,17 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:          ProviderUnstake,
+			Defaults:      unstakeDefaultEndpoints,
+			New:           withoutDb(NewUnstakeProvider),
+			NeedsDecimals: true,
+		},
+	)
+}
+
 func NewUnstakeProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -62,7 +73,7 @@ func NewUnstakeProvider(
 		provider.decimals[symbol] = uint64(decimals)
 	}
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -108,10 +119,12 @@ func (p *UnstakeProvider) Poll() error {
 			return err
 		}
 
-		p.setTickerPrice(
+		// unbonding per period is denominated in the quote asset
+		p.setTickerPriceWithUnit(
 			symbol,
 			price,
 			unbonding.Quo(period).Quo(uintToDec(10).Power(decimals)),
+			types.VolumeUnitQuote,
 			timestamp,
 		)
 	}
@@ -34,6 +34,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderVelodromeV2,
+			Defaults: velodromev2DefaultEndpoints,
+			New:      withoutDb(NewVelodromeV2Provider),
+		},
+	)
+}
+
 func NewVelodromeV2Provider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -56,7 +66,7 @@ func NewVelodromeV2Provider(
 	// get token decimals
 	provider.setDecimals()
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
 	"net/url"
 	"sync"
 	"time"
@@ -21,6 +22,7 @@ const (
 	disabledPingDuration      = time.Duration(0)
 	startingReconnectDuration = 5 * time.Second
 	maxRetryMultiplier        = 25 // max retry duration: 52m5s
+	defaultStreamTimeout      = 30 * time.Second
 )
 
 type (
@@ -28,6 +30,8 @@ type (
 
 	SubscribeHandler func(...types.CurrencyPair) []interface{}
 
+	URLHandler func() (url.URL, error)
+
 	// WebsocketController defines a provider agnostic websocket handler
 	// that manages reconnecting, subscribing, and receiving messages
 	WebsocketController struct {
@@ -39,6 +43,9 @@ type (
 		pairs               []types.CurrencyPair
 		messageHandler      MessageHandler
 		subscribeHandler    SubscribeHandler
+		urlHandler          URLHandler
+		dialer              *websocket.Dialer
+		header              http.Header
 		pingDuration        time.Duration
 		pingMessage         string
 		pingMessageType     uint
@@ -47,6 +54,8 @@ type (
 		mtx              sync.Mutex
 		client           *websocket.Conn
 		reconnectCounter uint
+		lastMessage      time.Time
+		panics           int // consecutive panics of the message handler
 	}
 )
 
@@ -101,7 +110,7 @@ func (wsc *WebsocketController) Start() {
 		go wsc.readWebSocket()
 		go wsc.pingLoop()
 
-		if err := wsc.subscribe(wsc.subscribeHandler(wsc.pairs...)); err != nil {
+		if err := wsc.subscribe(wsc.subscribeHandler(wsc.getPairs()...)); err != nil {
 			wsc.logger.Err(err).Send()
 			wsc.close()
 			continue
@@ -110,13 +119,65 @@ func (wsc *WebsocketController) Start() {
 	}
 }
 
+// SetURLHandler sets a handler that is called before every connection
+// attempt to get the websocket url, e.g. for providers that require a
+// token in the url.
+func (wsc *WebsocketController) SetURLHandler(urlHandler URLHandler) {
+	wsc.mtx.Lock()
+	defer wsc.mtx.Unlock()
+
+	wsc.urlHandler = urlHandler
+}
+
+// SetDialer sets the dialer and the headers used to connect, e.g. for
+// proxies or authentication.
+func (wsc *WebsocketController) SetDialer(dialer *websocket.Dialer, header http.Header) {
+	wsc.mtx.Lock()
+	defer wsc.mtx.Unlock()
+
+	wsc.dialer = dialer
+	wsc.header = header
+}
+
+// IsStreaming returns true if the websocket is connected and received a
+// message within the last defaultStreamTimeout.
+func (wsc *WebsocketController) IsStreaming() bool {
+	wsc.mtx.Lock()
+	defer wsc.mtx.Unlock()
+
+	return wsc.client != nil && time.Since(wsc.lastMessage) < defaultStreamTimeout
+}
+
 // connect dials the websocket and sets the client to the established connection
 func (wsc *WebsocketController) connect() error {
+	wsc.mtx.Lock()
+	urlHandler := wsc.urlHandler
+	wsc.mtx.Unlock()
+
+	// the url handler may request a token over http, don't block the other
+	// controller calls meanwhile
+	var websocketURL url.URL
+	if urlHandler != nil {
+		var err error
+		websocketURL, err = urlHandler()
+		if err != nil {
+			return fmt.Errorf(types.ErrWebsocketDial.Error(), wsc.providerName, err)
+		}
+	}
+
 	wsc.mtx.Lock()
 	defer wsc.mtx.Unlock()
 
+	if urlHandler != nil {
+		wsc.websocketURL = websocketURL
+	}
+
 	wsc.logger.Debug().Msg("connecting to websocket")
-	conn, resp, err := websocket.DefaultDialer.Dial(wsc.websocketURL.String(), nil)
+	dialer := websocket.DefaultDialer
+	if wsc.dialer != nil {
+		dialer = wsc.dialer
+	}
+	conn, resp, err := dialer.Dial(wsc.websocketURL.String(), wsc.header)
 	if err != nil {
 		return fmt.Errorf(types.ErrWebsocketDial.Error(), wsc.providerName, err)
 	}
@@ -138,7 +199,7 @@ func (wsc *WebsocketController) iterateRetryCounter() time.Duration {
 
 // subscribe sends the WebsocketControllers subscription messages to the websocket
 func (wsc *WebsocketController) subscribe(msgs []interface{}) error {
-	telemetryWebsocketSubscribeCurrencyPairs(wsc.providerName, len(wsc.pairs))
+	telemetryWebsocketSubscribeCurrencyPairs(wsc.providerName, len(wsc.getPairs()))
 	for _, jsonMessage := range msgs {
 		if err := wsc.SendJSON(jsonMessage); err != nil {
 			return fmt.Errorf(types.ErrWebsocketSend.Error(), wsc.providerName, err)
@@ -153,10 +214,24 @@ func (wsc *WebsocketController) AddSubscriptionMsgs(msgs []interface{}) error {
 	return wsc.subscribe(msgs)
 }
 
+// AddPairs subscribes to new pairs, they are subscribed to again after
+// reconnects.
 func (w *WebsocketController) AddPairs(pairs []types.CurrencyPair) error {
+	w.mtx.Lock()
+	w.pairs = append(w.pairs, pairs...)
+	w.mtx.Unlock()
+
 	return w.subscribe(w.subscribeHandler(pairs...))
 }
 
+// getPairs returns a copy of the pairs to subscribe to.
+func (w *WebsocketController) getPairs() []types.CurrencyPair {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return append([]types.CurrencyPair{}, w.pairs...)
+}
+
 // SendJSON sends a json message to the websocket connection using the Websocket
 // Controller mutex to ensure multiple writes do not happen at once
 func (wsc *WebsocketController) SendJSON(msg interface{}) error {
@@ -240,7 +315,13 @@ func (wsc *WebsocketController) readWebSocket() {
 				wsc.reconnect()
 				return
 			}
-			wsc.readSuccess(messageType, bz)
+			err = safeCall(wsc.providerName, componentWebsocket, wsc.logger, func() {
+				wsc.readSuccess(messageType, bz)
+			})
+			if err != nil {
+				wsc.restartAfterPanic()
+				return
+			}
 		case <-reconnectTicker.C:
 			wsc.reconnect()
 			return
@@ -256,7 +337,43 @@ func (wsc *WebsocketController) readSuccess(messageType int, bz []byte) {
 	if string(bz) == "pong" {
 		return
 	}
+
+	wsc.mtx.Lock()
+	wsc.lastMessage = time.Now()
+	wsc.mtx.Unlock()
+
 	wsc.messageHandler(messageType, bz)
+
+	wsc.mtx.Lock()
+	wsc.panics = 0
+	wsc.mtx.Unlock()
+}
+
+// restartAfterPanic closes the websocket and reconnects after a backoff.
+// After panicQuarantineCount consecutive panics the websocket stays closed
+// and the provider falls back to polling.
+func (wsc *WebsocketController) restartAfterPanic() {
+	wsc.close()
+
+	wsc.mtx.Lock()
+	wsc.panics++
+	panics := wsc.panics
+	wsc.mtx.Unlock()
+
+	if panics >= panicQuarantineCount {
+		wsc.logger.Error().
+			Int("panics", panics).
+			Msg("quarantined websocket after repeated panics")
+		return
+	}
+
+	go func() {
+		select {
+		case <-wsc.parentCtx.Done():
+		case <-time.After(panicBackoff(panics)):
+			wsc.Start()
+		}
+	}()
 }
 
 // close sends a close message to the websocket and sets the client to nil
@@ -1,9 +1,22 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"price-feeder/oracle/types"
+
+	"cosmossdk.io/math"
 	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 )
 
@@ -56,3 +69,254 @@ func TestWebsocketController_readSuccess(t *testing.T) {
 		})
 	}
 }
+
+func newTestWebsocketProvider(symbol string) provider {
+	return provider{
+		logger:  zerolog.Nop(),
+		tickers: map[string]types.TickerPrice{},
+		pairs:   map[string]types.CurrencyPair{symbol: testAtomUsdtCurrencyPair},
+		inverse: map[string]types.CurrencyPair{},
+	}
+}
+
+func TestProviders_websocketMessageReceived(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler func() (MessageHandler, *provider)
+		message string
+	}{
+		{
+			"binance",
+			func() (MessageHandler, *provider) {
+				p := &BinanceProvider{provider: newTestWebsocketProvider("ATOMUSDT")}
+				return p.messageReceived, &p.provider
+			},
+			`{"e":"24hrMiniTicker","E":1672515782136,"s":"ATOMUSDT","c":"12.3456","v":"7654321.98765"}`,
+		},
+		{
+			"kraken",
+			func() (MessageHandler, *provider) {
+				p := &KrakenProvider{provider: newTestWebsocketProvider("ATOMUSDT")}
+				p.symbols = map[string]string{"ATOM/USDT": "ATOMUSDT"}
+				return p.messageReceived, &p.provider
+			},
+			`[340,{"c":["12.3456","1.5"],"v":["100.0","7654321.98765"]},"ticker","ATOM/USDT"]`,
+		},
+		{
+			"coinbase",
+			func() (MessageHandler, *provider) {
+				p := &CoinbaseProvider{provider: newTestWebsocketProvider("ATOM-USDT")}
+				return p.messageReceived, &p.provider
+			},
+			`{"type":"ticker","product_id":"ATOM-USDT","price":"12.3456","volume_24h":"7654321.98765"}`,
+		},
+		{
+			"okx",
+			func() (MessageHandler, *provider) {
+				p := &OkxProvider{provider: newTestWebsocketProvider("ATOM-USDT")}
+				return p.messageReceived, &p.provider
+			},
+			`{"arg":{"channel":"tickers","instId":"ATOM-USDT"},"data":[{"instId":"ATOM-USDT","last":"12.3456","vol24h":"7654321.98765","ts":"` +
+				strconv.FormatInt(time.Now().UnixMilli(), 10) + `"}]}`,
+		},
+		{
+			"bybit",
+			func() (MessageHandler, *provider) {
+				p := &BybitProvider{provider: newTestWebsocketProvider("ATOMUSDT")}
+				return p.messageReceived, &p.provider
+			},
+			`{"topic":"tickers.ATOMUSDT","type":"snapshot","data":{"symbol":"ATOMUSDT","lastPrice":"12.3456","volume24h":"7654321.98765"}}`,
+		},
+		{
+			"kucoin",
+			func() (MessageHandler, *provider) {
+				p := &KucoinProvider{provider: newTestWebsocketProvider("ATOM-USDT")}
+				return p.messageReceived, &p.provider
+			},
+			`{"type":"message","topic":"/market/snapshot:ATOM-USDT","subject":"trade.snapshot","data":{"sequence":"1","data":{"symbol":"ATOM-USDT","lastTradedPrice":12.3456,"vol":7654321.98765}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, p := tc.handler()
+
+			// unrelated messages are ignored
+			handler(websocket.TextMessage, []byte(`{"event":"heartbeat"}`))
+			require.Empty(t, p.tickers)
+
+			handler(websocket.TextMessage, []byte(tc.message))
+			require.Len(t, p.tickers, 1)
+
+			ticker := p.tickers["ATOMUSDT"]
+			require.Equal(t, testAtomPriceDec, ticker.Price)
+			require.Equal(t, testAtomVolumeDec, ticker.Volume)
+		})
+	}
+}
+
+func TestBinanceProvider_websocketFallback(t *testing.T) {
+	var connections atomic.Int32
+	subscriptions := make(chan BinanceSubscriptionMsg, 1)
+	disconnect := make(chan struct{})
+
+	upgrader := websocket.Upgrader{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v3/ticker/24hr":
+			fmt.Fprintf(rw, `[{"symbol":"ATOMUSDT","lastPrice":"10","volume":"100","closeTime":%d}]`, time.Now().UnixMilli())
+
+		case "/ws":
+			// only accept the first connection to test the rest fallback
+			if connections.Add(1) > 1 {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			// require must not be called outside of the test goroutine
+			conn, err := upgrader.Upgrade(rw, req, nil)
+			if err != nil {
+				t.Errorf("failed to upgrade connection: %s", err)
+				return
+			}
+			defer conn.Close()
+
+			var msg BinanceSubscriptionMsg
+			if err := conn.ReadJSON(&msg); err != nil {
+				t.Errorf("failed to read subscription: %s", err)
+				return
+			}
+			subscriptions <- msg
+
+			for {
+				select {
+				case <-disconnect:
+					return
+				case <-time.After(20 * time.Millisecond):
+					ticker := fmt.Sprintf(`{"e":"24hrMiniTicker","E":%d,"s":"ATOMUSDT","c":"11","v":"200"}`, time.Now().UnixMilli())
+					err := conn.WriteMessage(websocket.TextMessage, []byte(ticker))
+					if err != nil {
+						return
+					}
+				}
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	endpoints := Endpoint{
+		Name:         ProviderBinanceUS,
+		Urls:         []string{server.URL},
+		Websocket:    "ws://" + strings.TrimPrefix(server.URL, "http://"),
+		PollInterval: 20 * time.Millisecond,
+	}
+
+	p, err := NewBinanceProvider(ctx, zerolog.Nop(), endpoints, testAtomUsdtCurrencyPair)
+	require.NoError(t, err)
+
+	select {
+	case msg := <-subscriptions:
+		bz, err := json.Marshal(msg.Params)
+		require.NoError(t, err)
+		require.Equal(t, `["atomusdt@miniTicker"]`, string(bz))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no subscription received")
+	}
+
+	hasPrice := func(expected int64) bool {
+		prices, err := p.GetTickerPrices(testAtomUsdtCurrencyPair)
+		require.NoError(t, err)
+		price := prices["ATOMUSDT"].Price
+		return !price.IsNil() && price.Equal(math.LegacyNewDec(expected))
+	}
+
+	require.Eventually(t, func() bool {
+		return p.isStreaming() && hasPrice(11)
+	}, 5*time.Second, 20*time.Millisecond)
+
+	close(disconnect)
+
+	require.Eventually(t, func() bool {
+		return !p.isStreaming() && hasPrice(10)
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestProvider_SubscribeCurrencyPairs(t *testing.T) {
+	osmoUsdtPair := types.CurrencyPair{Base: "OSMO", Quote: "USDT"}
+
+	t.Run("rest provider", func(t *testing.T) {
+		p := &provider{endpoints: Endpoint{Name: ProviderMexc}}
+		require.ErrorContains(t, p.SubscribeCurrencyPairs(osmoUsdtPair), "requires a restart")
+	})
+
+	t.Run("websocket provider", func(t *testing.T) {
+		subscriptions := make(chan BinanceSubscriptionMsg, 2)
+
+		upgrader := websocket.Upgrader{}
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case "/api/v3/ticker/24hr":
+				fmt.Fprint(rw, `[{"symbol":"ATOMUSDT"},{"symbol":"OSMOUSDT"}]`)
+
+			case "/ws":
+				// require must not be called outside of the test goroutine
+				conn, err := upgrader.Upgrade(rw, req, nil)
+				if err != nil {
+					t.Errorf("failed to upgrade connection: %s", err)
+					return
+				}
+				defer conn.Close()
+
+				for {
+					var msg BinanceSubscriptionMsg
+					if err := conn.ReadJSON(&msg); err != nil {
+						return
+					}
+					subscriptions <- msg
+				}
+			}
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		endpoints := Endpoint{
+			Name:         ProviderBinanceUS,
+			Urls:         []string{server.URL},
+			Websocket:    "ws://" + strings.TrimPrefix(server.URL, "http://"),
+			PollInterval: time.Minute,
+		}
+
+		p, err := NewBinanceProvider(ctx, zerolog.Nop(), endpoints, testAtomUsdtCurrencyPair)
+		require.NoError(t, err)
+
+		params := func() string {
+			select {
+			case msg := <-subscriptions:
+				bz, err := json.Marshal(msg.Params)
+				require.NoError(t, err)
+				return string(bz)
+			case <-time.After(5 * time.Second):
+				t.Fatal("no subscription received")
+				return ""
+			}
+		}
+
+		require.Equal(t, `["atomusdt@miniTicker"]`, params())
+
+		// pairs that are configured already are not subscribed to again
+		require.NoError(t, p.SubscribeCurrencyPairs(testAtomUsdtCurrencyPair, osmoUsdtPair))
+		require.Equal(t, `["osmousdt@miniTicker"]`, params())
+
+		p.mtx.RLock()
+		defer p.mtx.RUnlock()
+		require.Equal(t, osmoUsdtPair, p.pairs["OSMOUSDT"])
+		require.Equal(t, testAtomUsdtCurrencyPair, p.pairs["ATOMUSDT"])
+	})
+}
@@ -102,6 +102,67 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:          ProviderWhitewhaleCmdx,
+			Defaults:      whitewhaleCmdxDefaultEndpoints,
+			New:           withDb(NewWhitewhaleProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderWhitewhaleHuahua,
+			Defaults:      whitewhaleHuahuaDefaultEndpoints,
+			New:           withDb(NewWhitewhaleProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderWhitewhaleInj,
+			Defaults:      whitewhaleInjDefaultEndpoints,
+			New:           withDb(NewWhitewhaleProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderWhitewhaleJuno,
+			Defaults:      whitewhaleJunoDefaultEndpoints,
+			New:           withDb(NewWhitewhaleProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderWhitewhaleLunc,
+			Defaults:      whitewhaleLuncDefaultEndpoints,
+			New:           withDb(NewWhitewhaleProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderWhitewhaleLuna,
+			Defaults:      whitewhaleLunaDefaultEndpoints,
+			New:           withDb(NewWhitewhaleProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderWhitewhaleSei,
+			Defaults:      whitewhaleSeiDefaultEndpoints,
+			New:           withDb(NewWhitewhaleProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+		Registration{
+			Name:          ProviderWhitewhaleWhale,
+			Defaults:      whitewhaleWhaleDefaultEndpoints,
+			New:           withDb(NewWhitewhaleProvider),
+			NeedsDb:       true,
+			NeedsDecimals: true,
+		},
+	)
+}
+
 func NewWhitewhaleProvider(
 	db *sql.DB,
 	ctx context.Context,
@@ -131,7 +192,7 @@ func NewWhitewhaleProvider(
 		provider.denoms[asset.Denom] = symbol
 	}
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
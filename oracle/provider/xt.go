@@ -43,6 +43,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderXt,
+			Defaults: xtDefaultEndpoints,
+			New:      withoutDb(NewXtProvider),
+		},
+	)
+}
+
 func NewXtProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -62,7 +72,7 @@ func NewXtProvider(
 	availablePairs, _ := provider.GetAvailablePairs()
 	provider.setPairs(pairs, availablePairs, currencyPairToXtSymbol)
 
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
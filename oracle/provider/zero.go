@@ -28,6 +28,16 @@ type (
 	}
 )
 
+func init() {
+	Register(
+		Registration{
+			Name:     ProviderZero,
+			Defaults: zeroDefaultEndpoints,
+			New:      withoutDb(NewZeroProvider),
+		},
+	)
+}
+
 func NewZeroProvider(
 	ctx context.Context,
 	logger zerolog.Logger,
@@ -43,7 +53,7 @@ func NewZeroProvider(
 		nil,
 		nil,
 	)
-	go startPolling(provider, provider.endpoints.PollInterval, logger)
+	provider.startPolling(provider)
 	return provider, nil
 }
 
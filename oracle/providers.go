@@ -0,0 +1,213 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"price-feeder/oracle/provider"
+)
+
+const (
+	providerRetryMin = 5 * time.Second
+	providerRetryMax = 5 * time.Minute
+)
+
+// ProviderFailure describes a provider that failed to initialize and is
+// retried in the background.
+type ProviderFailure struct {
+	Error     string    `json:"error"`
+	Attempts  int       `json:"attempts"`
+	Since     time.Time `json:"since"`
+	NextRetry time.Time `json:"next_retry"`
+}
+
+// initProvider creates a provider with its configured endpoint, contract
+// addresses, decimals and periods. Panics of the constructor are returned
+// as errors. The provider is stopped when ctx is done.
+func (o *Oracle) initProvider(
+	ctx context.Context,
+	providerName provider.Name,
+) (priceProvider provider.Provider, err error) {
+	// constructors can fail after starting websockets and health checks,
+	// every attempt gets a context that stops them if it fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			o.logger.Error().
+				Str("provider", providerName.String()).
+				Str("stack", string(debug.Stack())).
+				Msg("recovered panic while initializing provider")
+		}
+	}()
+
+	// providers modify their endpoint, copy it to keep the configured one
+	// comparable on reloads
+	o.providersMtx.RLock()
+	endpoint := o.endpoints[providerName]
+	endpoint.Urls = slices.Clone(endpoint.Urls)
+	endpoint.ContractAddresses = maps.Clone(o.contractAddresses[providerName.String()])
+	endpoint.Decimals = o.decimals[providerName.String()]
+	endpoint.Periods = o.periods[providerName.String()]
+	pairs := o.providerPairs[providerName]
+	o.providersMtx.RUnlock()
+
+	if endpoint.ContractAddresses == nil {
+		endpoint.ContractAddresses = map[string]string{}
+	}
+
+	return NewProvider(
+		o.volumeDatabase,
+		provider.WithScheduler(ctx, o.scheduler),
+		providerName,
+		o.logger,
+		endpoint,
+		pairs...,
+	)
+}
+
+// providerFailed marks a provider as unavailable and retries to initialize
+// it in the background, with backoff, until it succeeds or the oracle is
+// stopped.
+func (o *Oracle) providerFailed(ctx context.Context, providerName provider.Name, err error) {
+	o.providersMtx.Lock()
+	defer o.providersMtx.Unlock()
+
+	if _, found := o.unavailableProviders[providerName]; found {
+		return
+	}
+
+	now := time.Now()
+	o.unavailableProviders[providerName] = &ProviderFailure{
+		Error:     err.Error(),
+		Attempts:  1,
+		Since:     now,
+		NextRetry: now.Add(providerRetryMin),
+	}
+
+	o.logger.Error().
+		Err(err).
+		Str("provider", providerName.String()).
+		Msg("failed to initialize provider, retrying in background")
+	provider.TelemetryProviderInitFailure(providerName)
+	provider.TelemetryProviderUnavailable(providerName, true)
+
+	go o.retryProvider(ctx, providerName)
+}
+
+func (o *Oracle) retryProvider(ctx context.Context, providerName provider.Name) {
+	backoff := providerRetryMin
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.closer.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		priceProvider, err := o.initProvider(ctx, providerName)
+
+		o.providersMtx.Lock()
+		failure, found := o.unavailableProviders[providerName]
+		// the provider was removed or replaced by a reload
+		if !found || ctx.Err() != nil {
+			o.providersMtx.Unlock()
+			return
+		}
+
+		if err == nil {
+			attempts := failure.Attempts + 1
+			o.priceProviders[providerName] = priceProvider
+			delete(o.unavailableProviders, providerName)
+			o.providersMtx.Unlock()
+
+			o.logger.Info().
+				Str("provider", providerName.String()).
+				Int("attempts", attempts).
+				Msg("initialized provider")
+			provider.TelemetryProviderUnavailable(providerName, false)
+			return
+		}
+
+		backoff *= 2
+		if backoff > providerRetryMax {
+			backoff = providerRetryMax
+		}
+
+		failure.Attempts++
+		failure.Error = err.Error()
+		failure.NextRetry = time.Now().Add(backoff)
+		o.providersMtx.Unlock()
+
+		o.logger.Warn().
+			Err(err).
+			Str("provider", providerName.String()).
+			Dur("retry_in", backoff).
+			Msg("failed to initialize provider")
+		provider.TelemetryProviderInitFailure(providerName)
+	}
+}
+
+// startProvider initializes a provider with a context of its own, so that
+// it can be stopped on reloads. Failed providers are retried in the
+// background.
+func (o *Oracle) startProvider(ctx context.Context, providerName provider.Name) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	o.providersMtx.Lock()
+	o.providerCancels[providerName] = cancel
+	o.providersMtx.Unlock()
+
+	priceProvider, err := o.initProvider(ctx, providerName)
+	if err != nil {
+		o.providerFailed(ctx, providerName, err)
+		return
+	}
+
+	o.providersMtx.Lock()
+	defer o.providersMtx.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+	o.priceProviders[providerName] = priceProvider
+}
+
+// stopProvider stops the poll loop, websocket and retries of a provider and
+// removes it, must be called with the providers lock held.
+func (o *Oracle) stopProvider(providerName provider.Name) {
+	if cancel, found := o.providerCancels[providerName]; found {
+		cancel()
+	}
+	o.scheduler.Remove(providerName)
+
+	delete(o.providerCancels, providerName)
+	delete(o.priceProviders, providerName)
+	if _, found := o.unavailableProviders[providerName]; found {
+		delete(o.unavailableProviders, providerName)
+		provider.TelemetryProviderUnavailable(providerName, false)
+	}
+}
+
+// GetUnavailableProviders returns the providers that failed to initialize
+// and are being retried.
+func (o *Oracle) GetUnavailableProviders() map[provider.Name]ProviderFailure {
+	o.providersMtx.RLock()
+	defer o.providersMtx.RUnlock()
+
+	unavailable := make(map[provider.Name]ProviderFailure, len(o.unavailableProviders))
+	for name, failure := range o.unavailableProviders {
+		unavailable[name] = *failure
+	}
+	return unavailable
+}
@@ -0,0 +1,204 @@
+package oracle
+
+import (
+	"fmt"
+	"reflect"
+
+	"cosmossdk.io/math"
+
+	"price-feeder/config"
+	"price-feeder/oracle/provider"
+	"price-feeder/oracle/types"
+)
+
+// Settings are the parts of the configuration that can be reloaded while
+// the oracle is running.
+type Settings struct {
+	CurrencyPairs        []config.CurrencyPair
+	Deviations           map[string]math.LegacyDec
+	ProviderMinOverrides map[string]int
+	Endpoints            map[provider.Name]provider.Endpoint
+	ContractAddresses    map[string]map[string]string
+	ProviderWeights      map[string]ProviderWeight
+	LiquidityFilters     map[string]LiquidityFilter
+	Pegs                 []Peg
+	Synthetics           []Synthetic
+	Precisions           map[string]Precision
+	Decimals             map[string]map[string]int
+	Periods              map[string]map[string]int
+}
+
+// Reload applies new settings between two ticks. Providers that were
+// removed, lost pairs, or whose endpoint, contract addresses, decimals or
+// periods changed, are stopped. Running providers that only gained pairs
+// subscribe to them. New and stopped providers are started by the next
+// tick, all other providers keep running. Derivative pairs can only be
+// changed with a restart.
+func (o *Oracle) Reload(settings Settings) error {
+	derivatives := 0
+	for _, pair := range settings.CurrencyPairs {
+		if pair.Derivative == "" {
+			continue
+		}
+		derivatives++
+		if _, found := o.derivativeSymbols[pair.Base+pair.Quote]; !found {
+			return fmt.Errorf("derivative pairs can only be changed with a restart")
+		}
+	}
+	if derivatives != len(o.derivativeSymbols) {
+		return fmt.Errorf("derivative pairs can only be changed with a restart")
+	}
+
+	providerPairs := getProviderPairs(settings.CurrencyPairs)
+
+	// providers subscribe to added pairs after the locks are released, so
+	// that slow endpoints don't block ticks, reloads stay exclusive
+	o.reloadMtx.Lock()
+	defer o.reloadMtx.Unlock()
+
+	subscriptions, added, removed, restarted := o.applySettings(settings, providerPairs)
+
+	subscribed := 0
+	for name, pending := range subscriptions {
+		err := pending.provider.SubscribeCurrencyPairs(pending.pairs...)
+		if err == nil {
+			subscribed++
+			continue
+		}
+
+		o.logger.Info().
+			Err(err).
+			Str("provider", name.String()).
+			Msg("restarting provider to add pairs")
+
+		o.tickMtx.Lock()
+		o.providersMtx.Lock()
+		o.stopProvider(name)
+		o.providersMtx.Unlock()
+		o.tickMtx.Unlock()
+		restarted++
+	}
+
+	o.logger.Info().
+		Int("added", added).
+		Int("removed", removed).
+		Int("restarted", restarted).
+		Int("subscribed", subscribed).
+		Msg("reloaded settings")
+
+	return nil
+}
+
+// subscription holds the pairs a running provider has to subscribe to.
+type subscription struct {
+	provider provider.Provider
+	pairs    []types.CurrencyPair
+}
+
+// applySettings stops removed and changed providers and replaces the
+// settings between two ticks. It returns the running providers that gained
+// pairs and the number of added, removed and restarted providers.
+func (o *Oracle) applySettings(
+	settings Settings,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+) (map[provider.Name]subscription, int, int, int) {
+	o.tickMtx.Lock()
+	defer o.tickMtx.Unlock()
+
+	o.providersMtx.Lock()
+	defer o.providersMtx.Unlock()
+
+	subscriptions := map[provider.Name]subscription{}
+	added, removed, restarted := 0, 0, 0
+	for name, pairs := range o.providerPairs {
+		newPairs, found := providerPairs[name]
+		if !found {
+			o.stopProvider(name)
+			removed++
+			continue
+		}
+		if o.providerChanged(name, settings) {
+			o.stopProvider(name)
+			restarted++
+			continue
+		}
+
+		addedPairs, pairsRemoved := diffPairs(pairs, newPairs)
+		if pairsRemoved {
+			o.stopProvider(name)
+			restarted++
+			continue
+		}
+		if len(addedPairs) == 0 {
+			continue
+		}
+
+		// providers that are not started yet get the new pairs on start
+		if priceProvider, found := o.priceProviders[name]; found {
+			subscriptions[name] = subscription{provider: priceProvider, pairs: addedPairs}
+		}
+	}
+	for name := range providerPairs {
+		if _, found := o.providerPairs[name]; !found {
+			added++
+		}
+	}
+
+	o.providerPairs = providerPairs
+	o.deviations = settings.Deviations
+	o.providerMinOverrides = settings.ProviderMinOverrides
+	o.endpoints = settings.Endpoints
+	o.contractAddresses = settings.ContractAddresses
+	o.providerWeights = settings.ProviderWeights
+	o.liquidityFilters = settings.LiquidityFilters
+	o.pegs = settings.Pegs
+	o.synthetics = settings.Synthetics
+	o.precisions = settings.Precisions
+	o.decimals = settings.Decimals
+	o.periods = settings.Periods
+
+	return subscriptions, added, removed, restarted
+}
+
+// providerChanged returns true if the configuration used to initialize a
+// provider differs between the running and the new settings.
+func (o *Oracle) providerChanged(name provider.Name, settings Settings) bool {
+	return !reflect.DeepEqual(o.endpoints[name], settings.Endpoints[name]) ||
+		!reflect.DeepEqual(o.contractAddresses[name.String()], settings.ContractAddresses[name.String()]) ||
+		!reflect.DeepEqual(o.decimals[name.String()], settings.Decimals[name.String()]) ||
+		!reflect.DeepEqual(o.periods[name.String()], settings.Periods[name.String()])
+}
+
+// getProviderPairs returns the currency pairs of each provider.
+func getProviderPairs(currencyPairs []config.CurrencyPair) map[provider.Name][]types.CurrencyPair {
+	providerPairs := make(map[provider.Name][]types.CurrencyPair)
+	for _, pair := range currencyPairs {
+		for _, provider := range pair.Providers {
+			providerPairs[provider] = append(providerPairs[provider], types.CurrencyPair{
+				Base:  pair.Base,
+				Quote: pair.Quote,
+			})
+		}
+	}
+	return providerPairs
+}
+
+// diffPairs returns the pairs of b that are not in a, and whether a
+// contains pairs that are not in b.
+func diffPairs(a, b []types.CurrencyPair) ([]types.CurrencyPair, bool) {
+	old := make(map[string]struct{}, len(a))
+	for _, pair := range a {
+		old[pair.String()] = struct{}{}
+	}
+
+	added := []types.CurrencyPair{}
+	for _, pair := range b {
+		if _, found := old[pair.String()]; found {
+			delete(old, pair.String())
+			continue
+		}
+		added = append(added, pair)
+	}
+
+	return added, len(old) > 0
+}
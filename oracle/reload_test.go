@@ -0,0 +1,110 @@
+package oracle
+
+import (
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"price-feeder/config"
+	"price-feeder/oracle/client"
+	"price-feeder/oracle/derivative"
+	"price-feeder/oracle/history"
+	"price-feeder/oracle/provider"
+	"price-feeder/oracle/types"
+)
+
+func TestReload(t *testing.T) {
+	oracle := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		[]config.CurrencyPair{
+			{Base: "ATOM", Quote: "USDT", Providers: []provider.Name{provider.ProviderBinance, provider.ProviderKraken}},
+			{Base: "OSMO", Quote: "USDT", Providers: []provider.Name{provider.ProviderBinance}},
+		},
+		time.Millisecond*100,
+		map[string]math.LegacyDec{},
+		map[string]int{},
+		map[provider.Name]provider.Endpoint{},
+		map[string]derivative.Derivative{},
+		map[string][]types.CurrencyPair{},
+		map[string]struct{}{},
+		nil,
+		history.PriceHistory{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		false,
+	)
+	defer oracle.Stop()
+
+	oracle.priceProviders[provider.ProviderBinance] = mockProvider{}
+	oracle.priceProviders[provider.ProviderKraken] = mockProvider{}
+	oracle.priceProviders[provider.ProviderOkx] = mockProvider{}
+
+	settings := Settings{
+		CurrencyPairs: []config.CurrencyPair{
+			// same pairs in a different order
+			{Base: "OSMO", Quote: "USDT", Providers: []provider.Name{provider.ProviderBinance}},
+			{Base: "ATOM", Quote: "USDT", Providers: []provider.Name{provider.ProviderBinance, provider.ProviderOkx}},
+			{Base: "ATOM", Quote: "USD", Providers: []provider.Name{provider.ProviderKraken}},
+		},
+		Deviations: map[string]math.LegacyDec{"ATOM": math.LegacyNewDec(2)},
+		Endpoints:  map[provider.Name]provider.Endpoint{},
+	}
+	require.NoError(t, oracle.Reload(settings))
+
+	// unchanged providers keep running, changed ones are stopped and
+	// started again by the next tick
+	require.Contains(t, oracle.priceProviders, provider.ProviderBinance)
+	require.NotContains(t, oracle.priceProviders, provider.ProviderKraken)
+	require.Contains(t, oracle.providerPairs, provider.ProviderOkx)
+	require.Equal(t, math.LegacyNewDec(2), oracle.deviations["ATOM"])
+
+	// added pairs are subscribed to by the running provider
+	subscriber := &subscribeProvider{}
+	oracle.priceProviders[provider.ProviderBinance] = subscriber
+	settings.CurrencyPairs = append(settings.CurrencyPairs, config.CurrencyPair{
+		Base:      "UMEE",
+		Quote:     "USDT",
+		Providers: []provider.Name{provider.ProviderBinance},
+	})
+	require.NoError(t, oracle.Reload(settings))
+	require.Contains(t, oracle.priceProviders, provider.ProviderBinance)
+	require.Equal(t, []types.CurrencyPair{{Base: "UMEE", Quote: "USDT"}}, subscriber.subscribed)
+
+	// changed endpoints restart the provider
+	settings.Endpoints = map[provider.Name]provider.Endpoint{
+		provider.ProviderBinance: {Name: provider.ProviderBinance, Urls: []string{"http://localhost"}},
+	}
+	require.NoError(t, oracle.Reload(settings))
+	require.NotContains(t, oracle.priceProviders, provider.ProviderBinance)
+
+	// derivatives can't be reloaded
+	settings.CurrencyPairs = append(settings.CurrencyPairs, config.CurrencyPair{
+		Base:       "ATOM",
+		Quote:      "USDC",
+		Providers:  []provider.Name{provider.ProviderBinance},
+		Derivative: "twap",
+	})
+	require.Error(t, oracle.Reload(settings))
+	require.NotContains(t, oracle.providerPairs[provider.ProviderBinance], types.CurrencyPair{Base: "ATOM", Quote: "USDC"})
+}
+
+type subscribeProvider struct {
+	mockProvider
+	subscribed []types.CurrencyPair
+}
+
+func (p *subscribeProvider) SubscribeCurrencyPairs(pairs ...types.CurrencyPair) error {
+	p.subscribed = append(p.subscribed, pairs...)
+	return nil
+}
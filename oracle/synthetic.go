@@ -0,0 +1,74 @@
+package oracle
+
+import (
+	"price-feeder/oracle/formula"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+)
+
+// Synthetic defines a denom whose price is computed by a formula over
+// other prices. Synthetics are expected to be sorted by their dependencies.
+type Synthetic struct {
+	Denom   string
+	Formula formula.Expr
+}
+
+// NewSynthetics parses the formulas by denom and returns them sorted so that
+// every synthetic is computed after the synthetics it depends on.
+func NewSynthetics(formulas map[string]string) ([]Synthetic, error) {
+	exprs := make(map[string]formula.Expr, len(formulas))
+	for denom, input := range formulas {
+		expr, err := formula.Parse(input)
+		if err != nil {
+			return nil, err
+		}
+		exprs[denom] = expr
+	}
+
+	ordered, err := formula.Order(exprs)
+	if err != nil {
+		return nil, err
+	}
+
+	synthetics := make([]Synthetic, 0, len(ordered))
+	for _, denom := range ordered {
+		synthetics = append(synthetics, Synthetic{
+			Denom:   denom,
+			Formula: exprs[denom],
+		})
+	}
+
+	return synthetics, nil
+}
+
+// ApplySynthetics computes the prices of all synthetic denoms from the
+// computed prices. Synthetics that depend on a missing price are skipped.
+func ApplySynthetics(
+	logger zerolog.Logger,
+	prices map[string]math.LegacyDec,
+	synthetics []Synthetic,
+) map[string]math.LegacyDec {
+	for _, synthetic := range synthetics {
+		price, err := synthetic.Formula.Eval(prices)
+		if err != nil {
+			logger.Error().
+				Err(err).
+				Str("denom", synthetic.Denom).
+				Msg("failed to compute synthetic price")
+			continue
+		}
+
+		if !price.IsPositive() {
+			logger.Error().
+				Str("denom", synthetic.Denom).
+				Str("price", price.String()).
+				Msg("synthetic price is not positive")
+			continue
+		}
+
+		prices[synthetic.Denom] = price
+	}
+
+	return prices
+}
@@ -7,11 +7,25 @@ import (
 	"cosmossdk.io/math"
 )
 
+const (
+	// VolumeUnitBase marks a volume denominated in the base asset of the pair.
+	VolumeUnitBase VolumeUnit = iota
+	// VolumeUnitQuote marks a volume denominated in the quote asset of the pair.
+	VolumeUnitQuote
+	// VolumeUnitUSD marks a volume that is already a USD notional.
+	VolumeUnitUSD
+)
+
+// VolumeUnit defines the denomination of a TickerPrice volume.
+type VolumeUnit uint8
+
 // TickerPrice defines price and volume information for a symbol or ticker exchange rate.
 type TickerPrice struct {
-	Price  math.LegacyDec `json:"price"`  // last trade price
-	Volume math.LegacyDec `json:"volume"` // 24h volume
-	Time   time.Time      `json:"time"`
+	Price      math.LegacyDec `json:"price"`  // last trade price
+	Volume     math.LegacyDec `json:"volume"` // 24h volume
+	VolumeUnit VolumeUnit     `json:"volume_unit,omitempty"`
+	Liquidity  math.LegacyDec `json:"-"` // pool liquidity in quote, nil if not reported
+	Time       time.Time      `json:"time"`
 }
 
 func NewTickerPrice(price string, volume string, timestamp time.Time) (TickerPrice, error) {
@@ -30,3 +44,48 @@ func NewTickerPrice(price string, volume string, timestamp time.Time) (TickerPri
 	}
 	return ticker, nil
 }
+
+// String implements the Stringer interface.
+func (u VolumeUnit) String() string {
+	switch u {
+	case VolumeUnitBase:
+		return "base"
+	case VolumeUnitQuote:
+		return "quote"
+	case VolumeUnitUSD:
+		return "usd"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(u))
+}
+
+// USDVolume returns the volume of the ticker as USD notional, given the USD
+// rate of the pair's quote asset. The ticker price is expected to be quoted
+// in the pair's quote asset.
+func (tp TickerPrice) USDVolume(quoteRate math.LegacyDec) math.LegacyDec {
+	if tp.Volume.IsNil() {
+		return math.LegacyZeroDec()
+	}
+
+	switch tp.VolumeUnit {
+	case VolumeUnitQuote:
+		return tp.Volume.Mul(quoteRate)
+	case VolumeUnitUSD:
+		return tp.Volume
+	default:
+		return tp.Volume.Mul(tp.Price).Mul(quoteRate)
+	}
+}
+
+// HasLiquidity returns true if the provider reported the pool liquidity.
+func (tp TickerPrice) HasLiquidity() bool {
+	return !tp.Liquidity.IsNil()
+}
+
+// USDLiquidity returns the pool liquidity as USD notional, given the USD rate
+// of the pair's quote asset.
+func (tp TickerPrice) USDLiquidity(quoteRate math.LegacyDec) math.LegacyDec {
+	if !tp.HasLiquidity() {
+		return math.LegacyDec{}
+	}
+	return tp.Liquidity.Mul(quoteRate)
+}
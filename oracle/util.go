@@ -7,6 +7,7 @@ import (
 	"price-feeder/oracle/types"
 
 	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
 )
 
 // ComputeVWAP computes the volume weighted average price for all tickers.
@@ -88,14 +89,54 @@ func SetWeight(
 		return rates, nil
 	}
 
+	// weights are used as is and must not be scaled by the USD notional
 	for name, volume := range weight.Weight {
 		providerName := provider.Name(name)
 		ticker, found := rates[providerName]
 		if found {
 			ticker.Volume = volume
+			ticker.VolumeUnit = types.VolumeUnitUSD
 			rates[providerName] = ticker
 		}
 	}
 
 	return rates, nil
 }
+
+// FilterTickerLiquidity removes tickers whose reported pool liquidity is below
+// the configured minimum. If liquidity weighting is enabled, the USD liquidity
+// replaces the volume of tickers that report one. Tickers without liquidity
+// information (e.g. from CEXes) are left untouched.
+func FilterTickerLiquidity(
+	logger zerolog.Logger,
+	symbol string,
+	tickerPrices map[provider.Name]types.TickerPrice,
+	filter LiquidityFilter,
+) map[provider.Name]types.TickerPrice {
+	filtered := map[provider.Name]types.TickerPrice{}
+	for providerName, tickerPrice := range tickerPrices {
+		if !tickerPrice.HasLiquidity() {
+			filtered[providerName] = tickerPrice
+			continue
+		}
+
+		if !filter.MinTvl.IsNil() && tickerPrice.Liquidity.LT(filter.MinTvl) {
+			logger.Debug().
+				Str("symbol", symbol).
+				Str("provider", providerName.String()).
+				Str("liquidity", tickerPrice.Liquidity.String()).
+				Str("min_tvl", filter.MinTvl.String()).
+				Msg("insufficient liquidity")
+			continue
+		}
+
+		if filter.Weighted {
+			tickerPrice.Volume = tickerPrice.Liquidity
+			tickerPrice.VolumeUnit = types.VolumeUnitUSD
+		}
+
+		filtered[providerName] = tickerPrice
+	}
+
+	return filtered
+}
@@ -0,0 +1,184 @@
+// Package plugintest is a conformance test kit for price-feeder plugins.
+//
+// Plugin authors run it from a go test against their plugin binary:
+//
+//	func TestConformance(t *testing.T) {
+//		plugintest.Run(t, plugintest.Command("./my-plugin"), plugin.Pair{Base: "ATOM", Quote: "USDT"})
+//	}
+package plugintest
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"price-feeder/pkg/plugin"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+)
+
+// Timeout is the time a plugin has to answer each step of the test.
+var Timeout = 10 * time.Second
+
+// Connect starts a plugin and returns its input, its output and a function
+// that waits for the plugin to exit.
+type Connect func(t *testing.T) (io.WriteCloser, io.Reader, func() error)
+
+// Command launches a plugin process, its stderr is passed through.
+func Command(name string, args ...string) Connect {
+	return func(t *testing.T) (io.WriteCloser, io.Reader, func() error) {
+		cmd := exec.Command(name, args...)
+		cmd.Stderr = os.Stderr
+
+		stdin, err := cmd.StdinPipe()
+		require.NoError(t, err)
+		stdout, err := cmd.StdoutPipe()
+		require.NoError(t, err)
+
+		require.NoError(t, cmd.Start())
+		t.Cleanup(func() { _ = cmd.Process.Kill() })
+
+		return stdin, stdout, cmd.Wait
+	}
+}
+
+// Func serves a source in process, for testing plugins written with
+// plugin.Serve without building them.
+func Func(source plugin.Source) Connect {
+	return func(t *testing.T) (io.WriteCloser, io.Reader, func() error) {
+		inReader, inWriter := io.Pipe()
+		outReader, outWriter := io.Pipe()
+
+		done := make(chan error, 1)
+		go func() {
+			err := plugin.Serve(context.Background(), inReader, outWriter, source)
+			outWriter.Close()
+			done <- err
+		}()
+
+		return inWriter, outReader, func() error { return <-done }
+	}
+}
+
+// Run checks that a plugin answers the init message with a ready message of
+// the same version and a non empty subset of the requested pairs, that it
+// sends valid tickers for every ready pair and only for those, and that it
+// exits once its input is closed.
+func Run(t *testing.T, connect Connect, pairs ...plugin.Pair) {
+	require.NotEmpty(t, pairs, "no pairs requested")
+
+	input, output, wait := connect(t)
+	conn := plugin.NewConn(output, input)
+
+	messages := make(chan plugin.Message)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			message, err := conn.Receive()
+			if err != nil {
+				errs <- err
+				return
+			}
+			messages <- message
+		}
+	}()
+
+	receive := func(step string) plugin.Message {
+		t.Helper()
+		for {
+			select {
+			case message := <-messages:
+				if message.Type == plugin.MessageError {
+					t.Logf("plugin error: %s", message.Error)
+					continue
+				}
+				return message
+			case err := <-errs:
+				require.FailNow(t, "connection closed", "%s: %v", step, err)
+			case <-time.After(Timeout):
+				require.FailNow(t, "timeout", "%s", step)
+			}
+		}
+	}
+
+	err := conn.Send(plugin.Message{
+		Type:         plugin.MessageInit,
+		Version:      plugin.Version,
+		Name:         "plugintest",
+		Pairs:        pairs,
+		PollInterval: "100ms",
+	})
+	require.NoError(t, err)
+
+	ready := receive("ready")
+	require.Equal(t, plugin.MessageReady, ready.Type, "expected ready message")
+	require.Equal(t, plugin.Version, ready.Version, "protocol version")
+	require.NotEmpty(t, ready.Pairs, "no pairs supported")
+
+	requested := map[string]struct{}{}
+	for _, pair := range pairs {
+		requested[pair.String()] = struct{}{}
+	}
+
+	supported := map[string]struct{}{}
+	missing := map[string]struct{}{}
+	for _, pair := range ready.Pairs {
+		_, found := requested[pair.String()]
+		require.True(t, found, "pair %s was not requested", pair)
+		supported[pair.String()] = struct{}{}
+		missing[pair.String()] = struct{}{}
+	}
+
+	for len(missing) > 0 {
+		message := receive("tickers")
+		require.Equal(t, plugin.MessageTickers, message.Type, "expected tickers message")
+
+		for _, ticker := range message.Tickers {
+			symbol := ticker.Base + ticker.Quote
+			_, found := supported[symbol]
+			require.True(t, found, "ticker %s is not a ready pair", symbol)
+
+			price, err := math.LegacyNewDecFromStr(ticker.Price)
+			require.NoError(t, err, "price of %s", symbol)
+			require.True(t, price.IsPositive(), "price of %s is not positive", symbol)
+
+			if ticker.Volume != "" {
+				volume, err := math.LegacyNewDecFromStr(ticker.Volume)
+				require.NoError(t, err, "volume of %s", symbol)
+				require.False(t, volume.IsNegative(), "volume of %s is negative", symbol)
+			}
+
+			require.Positive(t, ticker.Time, "time of %s", symbol)
+			require.WithinDuration(t, time.Now(), time.UnixMilli(ticker.Time), 24*time.Hour, "time of %s", symbol)
+
+			delete(missing, symbol)
+		}
+	}
+
+	// keep reading, a plugin may block on writes until it notices the close
+	go func() {
+		for {
+			select {
+			case <-messages:
+			case <-errs:
+				return
+			}
+		}
+	}()
+
+	require.NoError(t, input.Close())
+
+	exited := make(chan error, 1)
+	go func() { exited <- wait() }()
+
+	select {
+	case err := <-exited:
+		require.NoError(t, err, "plugin exit")
+	case <-time.After(Timeout):
+		require.FailNow(t, "plugin did not exit after its input was closed")
+	}
+}
@@ -0,0 +1,132 @@
+// Package plugin defines the protocol of out-of-process price providers.
+//
+// A plugin exchanges line-delimited JSON messages with the price-feeder,
+// either on stdin and stdout of a process launched by the price-feeder or on
+// a tcp connection the price-feeder dials. Anything a plugin writes to
+// stderr is logged by the price-feeder.
+//
+// The price-feeder starts with an init message containing the protocol
+// version, the provider name, the requested pairs and the poll interval:
+//
+//	{"type":"init","version":1,"name":"example","pairs":[{"base":"ATOM","quote":"USDT"}],"poll_interval":"5s"}
+//
+// The plugin answers with the supported subset of the requested pairs:
+//
+//	{"type":"ready","version":1,"pairs":[{"base":"ATOM","quote":"USDT"}]}
+//
+// Afterwards the plugin sends ticker updates of the supported pairs whenever
+// it has new data, prices and volumes are decimal strings, the volume is in
+// the base asset and the time is in unix milliseconds:
+//
+//	{"type":"tickers","tickers":[{"base":"ATOM","quote":"USDT","price":"10.5","volume":"1200","time":1700000000000}]}
+//
+// Errors are reported with {"type":"error","error":"..."}. Pairs added later
+// are requested with a subscribe message, which is answered by another ready
+// message. A plugin launched as a process exits when its stdin is closed.
+package plugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Version is the version of the protocol.
+const Version = 1
+
+const (
+	MessageInit      = "init"
+	MessageReady     = "ready"
+	MessageSubscribe = "subscribe"
+	MessageTickers   = "tickers"
+	MessageError     = "error"
+
+	maxMessageSize = 1024 * 1024
+)
+
+type (
+	// Message is a single line of the protocol.
+	Message struct {
+		Type         string   `json:"type"`
+		Version      int      `json:"version,omitempty"`
+		Name         string   `json:"name,omitempty"`
+		Pairs        []Pair   `json:"pairs,omitempty"`
+		PollInterval string   `json:"poll_interval,omitempty"`
+		Tickers      []Ticker `json:"tickers,omitempty"`
+		Error        string   `json:"error,omitempty"`
+	}
+
+	Pair struct {
+		Base  string `json:"base"`
+		Quote string `json:"quote"`
+	}
+
+	Ticker struct {
+		Base   string `json:"base"`
+		Quote  string `json:"quote"`
+		Price  string `json:"price"`
+		Volume string `json:"volume,omitempty"`
+		Time   int64  `json:"time"` // unix milliseconds
+	}
+
+	// Conn reads and writes messages, Send is safe for concurrent use.
+	Conn struct {
+		mtx     sync.Mutex
+		writer  io.Writer
+		scanner *bufio.Scanner
+	}
+)
+
+func (p Pair) String() string {
+	return p.Base + p.Quote
+}
+
+func NewConn(reader io.Reader, writer io.Writer) *Conn {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+
+	return &Conn{
+		writer:  writer,
+		scanner: scanner,
+	}
+}
+
+// Send writes a message as a single line.
+func (c *Conn) Send(message Message) error {
+	bz, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, err = c.writer.Write(append(bz, '\n'))
+	return err
+}
+
+// Receive reads the next message, io.EOF is returned once the connection is
+// closed.
+func (c *Conn) Receive() (Message, error) {
+	for c.scanner.Scan() {
+		line := c.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var message Message
+		err := json.Unmarshal(line, &message)
+		if err != nil {
+			return Message{}, fmt.Errorf("invalid message: %w", err)
+		}
+		return message, nil
+	}
+
+	err := c.scanner.Err()
+	if err == nil {
+		err = io.EOF
+	}
+	return Message{}, err
+}
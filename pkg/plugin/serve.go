@@ -0,0 +1,132 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const defaultPollInterval = 5 * time.Second
+
+// Source is implemented by plugins that are run with Serve.
+type Source interface {
+	// Pairs returns the supported pairs of the requested pairs.
+	Pairs(requested []Pair) ([]Pair, error)
+	// Tickers returns the latest tickers of the pairs.
+	Tickers(pairs []Pair) ([]Ticker, error)
+}
+
+// Serve implements the plugin side of the protocol for a source, the tickers
+// are polled at the interval requested by the price-feeder. It returns nil
+// once the reader is closed.
+func Serve(ctx context.Context, reader io.Reader, writer io.Writer, source Source) error {
+	conn := NewConn(reader, writer)
+
+	init, err := conn.Receive()
+	if err != nil {
+		return err
+	}
+
+	if init.Type != MessageInit {
+		return fmt.Errorf("expected init message, got %s", init.Type)
+	}
+
+	if init.Version != Version {
+		err = fmt.Errorf("unsupported protocol version %d", init.Version)
+		_ = conn.Send(Message{Type: MessageError, Error: err.Error()})
+		return err
+	}
+
+	interval := defaultPollInterval
+	if init.PollInterval != "" {
+		interval, err = time.ParseDuration(init.PollInterval)
+		if err != nil {
+			return err
+		}
+	}
+
+	pairs, err := source.Pairs(init.Pairs)
+	if err != nil {
+		return err
+	}
+
+	err = conn.Send(Message{Type: MessageReady, Version: Version, Pairs: pairs})
+	if err != nil {
+		return err
+	}
+
+	// the reader exits on the next message or error once Serve returned
+	done := make(chan struct{})
+	defer close(done)
+
+	messages := make(chan Message)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			message, err := conn.Receive()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case messages <- message:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		tickers, err := source.Tickers(pairs)
+		if err != nil {
+			err = conn.Send(Message{Type: MessageError, Error: err.Error()})
+		} else if len(tickers) > 0 {
+			err = conn.Send(Message{Type: MessageTickers, Tickers: tickers})
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case message := <-messages:
+			if message.Type != MessageSubscribe {
+				continue
+			}
+			requested := append(append([]Pair{}, pairs...), message.Pairs...)
+			pairs, err = source.Pairs(uniquePairs(requested))
+			if err != nil {
+				return err
+			}
+			err = conn.Send(Message{Type: MessageReady, Version: Version, Pairs: pairs})
+			if err != nil {
+				return err
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+func uniquePairs(pairs []Pair) []Pair {
+	seen := map[Pair]struct{}{}
+	unique := []Pair{}
+	for _, pair := range pairs {
+		if _, found := seen[pair]; found {
+			continue
+		}
+		seen[pair] = struct{}{}
+		unique = append(unique, pair)
+	}
+	return unique
+}
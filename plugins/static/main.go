@@ -0,0 +1,100 @@
+// Command static is the reference price-feeder plugin. It serves fixed
+// prices given as arguments on stdin and stdout, ex.:
+//
+//	static ATOM/USDT=10.5 OSMO/USDT=0.8
+//
+// and is configured in the price-feeder with:
+//
+//	[[plugin]]
+//	name = "static"
+//	command = ["static", "ATOM/USDT=10.5"]
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"price-feeder/pkg/plugin"
+
+	"cosmossdk.io/math"
+)
+
+type staticSource struct {
+	prices map[plugin.Pair]string
+}
+
+func newStaticSource(args []string) (*staticSource, error) {
+	source := &staticSource{prices: map[plugin.Pair]string{}}
+
+	for _, arg := range args {
+		symbol, price, found := strings.Cut(arg, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid price %s, expected BASE/QUOTE=PRICE", arg)
+		}
+
+		base, quote, found := strings.Cut(symbol, "/")
+		if !found {
+			return nil, fmt.Errorf("invalid pair %s, expected BASE/QUOTE", symbol)
+		}
+
+		if _, err := math.LegacyNewDecFromStr(price); err != nil {
+			return nil, fmt.Errorf("invalid price %s: %w", price, err)
+		}
+
+		pair := plugin.Pair{Base: strings.ToUpper(base), Quote: strings.ToUpper(quote)}
+		source.prices[pair] = price
+	}
+
+	return source, nil
+}
+
+func (s *staticSource) Pairs(requested []plugin.Pair) ([]plugin.Pair, error) {
+	pairs := []plugin.Pair{}
+	for _, pair := range requested {
+		if _, found := s.prices[pair]; found {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs, nil
+}
+
+func (s *staticSource) Tickers(pairs []plugin.Pair) ([]plugin.Ticker, error) {
+	now := time.Now().UnixMilli()
+
+	tickers := []plugin.Ticker{}
+	for _, pair := range pairs {
+		price, found := s.prices[pair]
+		if !found {
+			continue
+		}
+		tickers = append(tickers, plugin.Ticker{
+			Base:  pair.Base,
+			Quote: pair.Quote,
+			Price: price,
+			Time:  now,
+		})
+	}
+	return tickers, nil
+}
+
+func main() {
+	source, err := newStaticSource(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	err = plugin.Serve(ctx, os.Stdin, os.Stdout, source)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
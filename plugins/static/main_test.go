@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"price-feeder/pkg/plugin"
+	"price-feeder/pkg/plugin/plugintest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticSource_Conformance(t *testing.T) {
+	source, err := newStaticSource([]string{"ATOM/USDT=10.5", "OSMO/USDT=0.8"})
+	require.NoError(t, err)
+
+	plugintest.Run(t, plugintest.Func(source),
+		plugin.Pair{Base: "ATOM", Quote: "USDT"},
+		plugin.Pair{Base: "OSMO", Quote: "USDT"},
+		// not supported
+		plugin.Pair{Base: "BTC", Quote: "USDT"},
+	)
+}
+
+func TestNewStaticSource(t *testing.T) {
+	_, err := newStaticSource([]string{"ATOMUSDT=10.5"})
+	require.Error(t, err)
+
+	_, err = newStaticSource([]string{"ATOM/USDT=abc"})
+	require.Error(t, err)
+}
@@ -4,10 +4,22 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"price-feeder/oracle"
+	"price-feeder/oracle/provider"
 )
 
 // Oracle defines the Oracle interface contract that the v1 router depends on.
 type Oracle interface {
 	GetLastPriceSyncTimestamp() time.Time
 	GetPrices() sdk.DecCoins
+	GetProviderStatus() map[provider.Name]provider.PollStatus
+	GetUnavailableProviders() map[provider.Name]oracle.ProviderFailure
+	PauseProvider(providerName provider.Name) bool
+	ResumeProvider(providerName provider.Name) bool
+}
+
+// Reloader reloads the configuration of the running price feeder.
+type Reloader interface {
+	Reload() error
 }
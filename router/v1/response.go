@@ -5,11 +5,17 @@ import (
 	"net/http"
 
 	"cosmossdk.io/math"
+
+	"price-feeder/oracle"
+	"price-feeder/oracle/provider"
 )
 
 // Response constants
 const (
 	StatusAvailable = "available"
+	StatusReloaded  = "reloaded"
+	StatusPaused    = "paused"
+	StatusResumed   = "resumed"
 )
 
 type (
@@ -26,6 +32,25 @@ type (
 	PricesResponse struct {
 		Prices map[string]math.LegacyDec `json:"prices"`
 	}
+
+	// ReloadResponse defines the response type for reloading the config.
+	ReloadResponse struct {
+		Status string `json:"status"`
+	}
+
+	// ProviderControlResponse defines the response type for pausing and
+	// resuming a provider.
+	ProviderControlResponse struct {
+		Provider provider.Name `json:"provider"`
+		Status   string        `json:"status"`
+	}
+
+	// ProvidersResponse defines the response type for getting the poll
+	// status of the providers and the providers that failed to initialize.
+	ProvidersResponse struct {
+		Providers   map[provider.Name]provider.PollStatus    `json:"providers"`
+		Unavailable map[provider.Name]oracle.ProviderFailure `json:"unavailable"`
+	}
 )
 
 // errorResponse defines the attributes of a JSON error response.
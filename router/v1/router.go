@@ -11,6 +11,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"price-feeder/config"
+	"price-feeder/oracle/provider"
 	"price-feeder/pkg/httputil"
 	"price-feeder/router/middleware"
 )
@@ -21,10 +22,11 @@ const (
 
 // Router defines a router wrapper used for registering v1 API routes.
 type Router struct {
-	logger  zerolog.Logger
-	cfg     config.Config
-	oracle  Oracle
-	metrics Metrics
+	logger   zerolog.Logger
+	cfg      config.Config
+	oracle   Oracle
+	metrics  Metrics
+	reloader Reloader
 }
 
 func New(logger zerolog.Logger, cfg config.Config, oracle Oracle, metrics Metrics) *Router {
@@ -36,6 +38,12 @@ func New(logger zerolog.Logger, cfg config.Config, oracle Oracle, metrics Metric
 	}
 }
 
+// SetReloader sets the reloader of the reload route, which is only
+// registered if reloading is enabled in the server config.
+func (r *Router) SetReloader(reloader Reloader) {
+	r.reloader = reloader
+}
+
 // RegisterRoutes register v1 API routes on the provided sub-router.
 func (r *Router) RegisterRoutes(rtr *mux.Router, prefix string) {
 	v1Router := rtr.PathPrefix(prefix).Subrouter()
@@ -70,6 +78,30 @@ func (r *Router) RegisterRoutes(rtr *mux.Router, prefix string) {
 		mChain.ThenFunc(r.pricesHandler()),
 	).Methods(httputil.MethodGET)
 
+	v1Router.Handle(
+		"/providers",
+		mChain.ThenFunc(r.providersHandler()),
+	).Methods(httputil.MethodGET)
+
+	if r.cfg.Server.EnableProviderControl {
+		v1Router.Handle(
+			"/providers/{name}/pause",
+			mChain.ThenFunc(r.providerControlHandler(r.oracle.PauseProvider, StatusPaused)),
+		).Methods(httputil.MethodPOST)
+
+		v1Router.Handle(
+			"/providers/{name}/resume",
+			mChain.ThenFunc(r.providerControlHandler(r.oracle.ResumeProvider, StatusResumed)),
+		).Methods(httputil.MethodPOST)
+	}
+
+	if r.cfg.Server.EnableReload && r.reloader != nil {
+		v1Router.Handle(
+			"/reload",
+			mChain.ThenFunc(r.reloadHandler()),
+		).Methods(httputil.MethodPOST)
+	}
+
 	if r.cfg.Telemetry.Enabled {
 		v1Router.Handle(
 			"/metrics",
@@ -104,6 +136,47 @@ func (r *Router) pricesHandler() http.HandlerFunc {
 	}
 }
 
+func (r *Router) providersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		resp := ProvidersResponse{
+			Providers:   r.oracle.GetProviderStatus(),
+			Unavailable: r.oracle.GetUnavailableProviders(),
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, resp)
+	}
+}
+
+// providerControlHandler pauses or resumes the provider named in the path.
+func (r *Router) providerControlHandler(
+	control func(providerName provider.Name) bool,
+	status string,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		providerName := provider.Name(mux.Vars(req)["name"])
+		if !control(providerName) {
+			writeErrorResponse(w, http.StatusNotFound, fmt.Sprintf("unknown provider: %s", providerName))
+			return
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, ProviderControlResponse{
+			Provider: providerName,
+			Status:   status,
+		})
+	}
+}
+
+func (r *Router) reloadHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if err := r.reloader.Reload(); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("failed to reload config: %s", err))
+			return
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, ReloadResponse{Status: StatusReloaded})
+	}
+}
+
 func (r *Router) metricsHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		format := strings.TrimSpace(req.FormValue("format"))
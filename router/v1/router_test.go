@@ -7,12 +7,15 @@ import (
 	"testing"
 	"time"
 
+	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/suite"
 
 	"price-feeder/config"
+	"price-feeder/oracle"
+	"price-feeder/oracle/provider"
 	v1 "price-feeder/router/v1"
 
 	"github.com/cosmos/cosmos-sdk/telemetry"
@@ -21,7 +24,7 @@ import (
 var (
 	_ v1.Oracle = (*mockOracle)(nil)
 
-	mockPrices = math.LegacyDecCoins{
+	mockPrices = sdk.DecCoins{
 		sdk.NewDecCoinFromDec("ATOM", math.LegacyNewDecFromStr("34.84")),
 		sdk.NewDecCoinFromDec("UMEE", math.LegacyNewDecFromStr("4.21")),
 	}
@@ -33,10 +36,30 @@ func (m mockOracle) GetLastPriceSyncTimestamp() time.Time {
 	return time.Now()
 }
 
-func (m mockOracle) GetPrices() math.LegacyDecCoins {
+func (m mockOracle) GetPrices() sdk.DecCoins {
 	return mockPrices
 }
 
+func (m mockOracle) GetProviderStatus() map[provider.Name]provider.PollStatus {
+	return map[provider.Name]provider.PollStatus{
+		provider.ProviderBinance: {Interval: time.Second},
+	}
+}
+
+func (m mockOracle) GetUnavailableProviders() map[provider.Name]oracle.ProviderFailure {
+	return map[provider.Name]oracle.ProviderFailure{
+		provider.ProviderOsmosisV2: {Error: "connection refused", Attempts: 2},
+	}
+}
+
+func (m mockOracle) PauseProvider(providerName provider.Name) bool {
+	return providerName == provider.ProviderBinance
+}
+
+func (m mockOracle) ResumeProvider(providerName provider.Name) bool {
+	return providerName == provider.ProviderBinance
+}
+
 type mockMetrics struct{}
 
 func (mockMetrics) Gather(format string) (telemetry.GatherResponse, error) {
@@ -55,8 +78,9 @@ func (rts *RouterTestSuite) SetupSuite() {
 	mux := mux.NewRouter()
 	cfg := config.Config{
 		Server: config.Server{
-			AllowedOrigins: []string{},
-			VerboseCORS:    false,
+			AllowedOrigins:        []string{},
+			VerboseCORS:           false,
+			EnableProviderControl: true,
 		},
 	}
 
@@ -103,3 +127,35 @@ func (rts *RouterTestSuite) TestPrices() {
 	rts.Require().Equal(respBody.Prices["UMEE"], mockPrices.AmountOf("UMEE"))
 	rts.Require().Equal(respBody.Prices["FOO"], math.LegacyDec{})
 }
+
+func (rts *RouterTestSuite) TestProviders() {
+	req, err := http.NewRequest("GET", "/api/v1/providers", nil)
+	rts.Require().NoError(err)
+
+	response := rts.executeRequest(req)
+	rts.Require().Equal(http.StatusOK, response.Code)
+
+	var respBody v1.ProvidersResponse
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+	rts.Require().Equal(time.Second, respBody.Providers[provider.ProviderBinance].Interval)
+	rts.Require().Equal(2, respBody.Unavailable[provider.ProviderOsmosisV2].Attempts)
+}
+
+func (rts *RouterTestSuite) TestProviderControl() {
+	req, err := http.NewRequest("POST", "/api/v1/providers/binance/pause", nil)
+	rts.Require().NoError(err)
+
+	response := rts.executeRequest(req)
+	rts.Require().Equal(http.StatusOK, response.Code)
+
+	var respBody v1.ProviderControlResponse
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+	rts.Require().Equal(provider.ProviderBinance, respBody.Provider)
+	rts.Require().Equal(v1.StatusPaused, respBody.Status)
+
+	req, err = http.NewRequest("POST", "/api/v1/providers/kraken/resume", nil)
+	rts.Require().NoError(err)
+
+	response = rts.executeRequest(req)
+	rts.Require().Equal(http.StatusNotFound, response.Code)
+}